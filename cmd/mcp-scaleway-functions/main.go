@@ -11,14 +11,19 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/httpauth"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/middlewares"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/xdg"
 	"github.com/cyclimse/mcp-scaleway-functions/pkg/scwslog"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogredact"
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/lmittmann/tint"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	slogmulti "github.com/samber/slog-multi"
@@ -28,8 +33,13 @@ import (
 )
 
 const (
-	sseTransport   = "sse"
-	stdioTransport = "stdio"
+	sseTransport            = "sse"
+	stdioTransport          = "stdio"
+	streamableHTTPTransport = "streamable-http"
+)
+
+var errMissingAuthResourceURL = errors.New(
+	"--auth-resource-url is required when OAuth is enabled and the HTTP host is not a loopback address",
 )
 
 type cliContext struct {
@@ -43,61 +53,107 @@ var cli struct {
 
 	LogLevel slog.Level `help:"Log level (debug, info, warn, error)."`
 
+	RedactPaths []string `help:"Extra JSON paths to mask in the logged tool inputs and results (e.g. $.headers.Authorization)."`
+
 	Serve serveCmd `cmd:"" default:"withargs" help:"Start the MCP server."`
+	Watch watchCmd `cmd:""                    help:"Redeploy a function every time its code changes."`
+	Plan  planCmd  `cmd:""                    help:"List the changes needed to match a manifest."`
+	Apply applyCmd `cmd:""                    help:"Apply the changes needed to match a manifest."`
 }
 
 type serveCmd struct {
 	Profile string `help:"Scaleway profile to use (overrides the active profile)." short:"p"`
 
-	Transport string `default:"sse" enum:"sse,stdio" help:"Transport to use (sse or stdio)."`
+	Transport string `default:"sse" enum:"sse,stdio,streamable-http" help:"Transport to use (sse, stdio or streamable-http)."`
 
 	HTTPHost string `default:"localhost" help:"HTTP host to listen on."`
 	HTTPPort int    `default:"8080"      help:"HTTP port to listen on."`
+
+	Stateless      bool          `help:"Do not track sessions (streamable-http only)."`
+	Resumption     bool          `help:"Allow clients to resume interrupted streams (streamable-http only)." default:"true" negatable:""`
+	SessionTimeout time.Duration `help:"Close sessions idle for this long (streamable-http only, 0 to disable)." default:"30m"`
+
+	ReadOnly     bool     `help:"Only enable tools which do not modify Scaleway resources."`
+	EnableTools  []string `help:"Only enable the listed tools."`
+	DisableTools []string `help:"Disable the listed tools."`
+
+	Auth authFlags `embed:"" prefix:"auth-" group:"Authentication (sse and streamable-http only)"`
 }
 
-func (cmd *serveCmd) Run(cliCtx *cliContext) error {
-	logger := cliCtx.Logger
+type authFlags struct {
+	Tokens      []string `help:"Static bearer tokens accepted by the server." env:"MCP_SCALEWAY_FUNCTIONS_AUTH_TOKENS"`
+	ResourceURL string   `help:"Public URL of the server, advertised in the OAuth protected resource metadata (defaults to http://<http-host>:<http-port> on loopback hosts)."`
 
-	scwlogger.SetLogger(scwslog.NewLogger(logger))
+	AuthorizationServers []string `help:"OAuth 2.1 authorization servers issuing access tokens for the server."`
+	IntrospectionURL     string   `help:"OAuth 2.0 token introspection endpoint used to validate access tokens."`
+	ClientID             string   `help:"Client ID used to authenticate to the introspection endpoint."`
+	ClientSecret         string   `help:"Client secret used to authenticate to the introspection endpoint." env:"MCP_SCALEWAY_FUNCTIONS_AUTH_CLIENT_SECRET"`
+	Scopes               []string `help:"Scopes required on access tokens."`
+}
 
-	if cliCtx.Debug {
-		scwlogger.EnableDebugMode()
+func (f authFlags) ToConfig(defaultResourceURL string) httpauth.Config {
+	resourceURL := f.ResourceURL
+	if resourceURL == "" {
+		resourceURL = defaultResourceURL
 	}
 
-	p, err := loadScalewayProfile(cmd.Profile)
-	if err != nil {
-		return fmt.Errorf("loading Scaleway profile: %w", err)
+	return httpauth.Config{
+		Tokens:               f.Tokens,
+		ResourceURL:          resourceURL,
+		AuthorizationServers: f.AuthorizationServers,
+		IntrospectionURL:     f.IntrospectionURL,
+		ClientID:             f.ClientID,
+		ClientSecret:         f.ClientSecret,
+		Scopes:               f.Scopes,
 	}
+}
 
-	projectID := p.DefaultProjectID
-	if projectID == nil {
-		logger.Warn("No default project ID set in Scaleway profile; some operations may fail.")
+// Validate is called by kong once the flags are parsed.
+func (cmd *serveCmd) Validate() error {
+	// OAuth access tokens are issued for the public URL of the server, which is unlikely to be
+	// the address it listens on, unless it's only reachable from the same machine.
+	oauthEnabled := cmd.Transport != stdioTransport && cmd.Auth.IntrospectionURL != ""
+	if oauthEnabled && cmd.Auth.ResourceURL == "" && !isLoopbackHost(cmd.HTTPHost) {
+		return errMissingAuthResourceURL
 	}
 
-	scwClient, err := scw.NewClient(
-		scw.WithProfile(p),
-		scw.WithUserAgent(constants.UserAgent),
-	)
+	return nil
+}
+
+func (cmd *serveCmd) Run(cliCtx *cliContext) error {
+	logger := cliCtx.Logger
+
+	scwClient, err := newScalewayClient(cliCtx, cmd.Profile)
 	if err != nil {
-		return fmt.Errorf("creating Scaleway client: %w", err)
+		return err
 	}
 
 	if err := warnOnExcessivePermissions(context.Background(), logger, scwClient); err != nil {
 		return fmt.Errorf("warning about permissions: %w", err)
 	}
 
-	tools := scaleway.NewTools(scwClient, *projectID)
+	toolFilter := scaleway.ToolFilter{
+		ReadOnly: cmd.ReadOnly,
+		Enabled:  cmd.EnableTools,
+		Disabled: cmd.DisableTools,
+	}
+
+	tools := scaleway.NewTools(scwClient)
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    constants.ProjectName,
 		Title:   "MCP Scaleway Serverless Functions",
 		Version: constants.Version,
-	}, nil)
+	}, &mcp.ServerOptions{
+		Instructions: toolFilter.Instructions(),
+	})
 	server.AddReceivingMiddleware(
 		middlewares.NewInjectLogger(logger),
 		middlewares.NewLogging(),
 	)
 
-	tools.Register(server)
+	if err := tools.Register(server, toolFilter); err != nil {
+		return fmt.Errorf("registering tools: %w", err)
+	}
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
@@ -107,24 +163,73 @@ func (cmd *serveCmd) Run(cliCtx *cliContext) error {
 		return cmd.startSSE(ctx, logger, server)
 	case stdioTransport:
 		return cmd.startStdio(ctx, logger, server)
+	case streamableHTTPTransport:
+		return cmd.startStreamableHTTP(ctx, logger, server)
 	default:
 		//nolint:err113 // can't be caught anyway
 		return fmt.Errorf("unknown transport: %s", cmd.Transport)
 	}
 }
 
-//nolint:contextcheck // shutdown context does not inherit from parent, which is intentional
 func (cmd *serveCmd) startSSE(ctx context.Context, logger *slog.Logger, server *mcp.Server) error {
 	handler := mcp.NewSSEHandler(func(*http.Request) *mcp.Server { return server }, nil)
+
+	return cmd.serveHTTP(ctx, logger.With("transport", sseTransport), handler)
+}
+
+func (cmd *serveCmd) startStreamableHTTP(
+	ctx context.Context,
+	logger *slog.Logger,
+	server *mcp.Server,
+) error {
+	opts := &mcp.StreamableHTTPOptions{
+		Stateless:      cmd.Stateless,
+		Logger:         logger,
+		SessionTimeout: cmd.SessionTimeout,
+	}
+
+	// Resuming a stream requires a session to attach it to.
+	if cmd.Resumption && !cmd.Stateless {
+		opts.EventStore = mcp.NewMemoryEventStore(nil)
+	}
+
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, opts)
+
+	return cmd.serveHTTP(ctx, logger.With("transport", streamableHTTPTransport), handler)
+}
+
+// serveHTTP serves the given MCP handler until the context is done, after which the server
+// is gracefully shut down.
+//
+//nolint:contextcheck // shutdown context does not inherit from parent, which is intentional
+func (cmd *serveCmd) serveHTTP(
+	ctx context.Context,
+	logger *slog.Logger,
+	handler http.Handler,
+) error {
 	addr := net.JoinHostPort(cmd.HTTPHost, strconv.Itoa(cmd.HTTPPort))
 
+	authConfig := cmd.Auth.ToConfig("http://" + addr)
+	if authConfig.Enabled() {
+		var err error
+
+		handler, err = httpauth.Wrap(authConfig, handler)
+		if err != nil {
+			return fmt.Errorf("configuring authentication: %w", err)
+		}
+
+		logger.Info("Authentication enabled", "resource_url", authConfig.ResourceURL)
+	} else if !isLoopbackHost(cmd.HTTPHost) {
+		logger.Warn("Authentication is disabled: anyone who can reach the server can manage your functions.")
+	}
+
 	httpServer := http.Server{
 		Handler:           handler,
 		Addr:              addr,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
-	logger.Info("Starting server in SSE mode...", "addr", addr)
+	logger.Info("Starting HTTP server...", "addr", addr)
 
 	go func() {
 		<-ctx.Done()
@@ -150,13 +255,27 @@ func (cmd *serveCmd) startSSE(ctx context.Context, logger *slog.Logger, server *
 	return nil
 }
 
-func (*serveCmd) startStdio(
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+func (cmd *serveCmd) startStdio(
 	ctx context.Context,
 	logger *slog.Logger,
 	server *mcp.Server,
 ) error {
 	logger.Info("Starting server in stdio mode...")
 
+	if cmd.Auth.ToConfig("").Enabled() {
+		logger.Warn("Authentication flags are ignored with the stdio transport.")
+	}
+
 	err := server.Run(ctx, &mcp.StdioTransport{})
 	if err != nil {
 		return fmt.Errorf("running server: %w", err)
@@ -173,7 +292,12 @@ func main() {
 		logLevel = slog.LevelDebug
 	}
 
-	logger, err := createLogger(logLevel, cli.Serve.Transport)
+	redactor, err := createRedactor(cli.RedactPaths)
+	if err != nil {
+		ctx.FatalIfErrorf(fmt.Errorf("creating redactor: %w", err))
+	}
+
+	logger, err := createLogger(logLevel, cli.Serve.Transport, redactor)
 	if err != nil {
 		ctx.FatalIfErrorf(fmt.Errorf("creating logger: %w", err))
 	}
@@ -188,18 +312,53 @@ func main() {
 	ctx.FatalIfErrorf(err)
 }
 
-func createLogger(logLevel slog.Level, transport string) (*slog.Logger, error) {
-	xdgStateDir := os.Getenv("XDG_STATE_HOME")
-	if xdgStateDir == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("getting user home directory: %w", err)
+// defaultRedactPaths masks the credentials in the headers of "invoke_function" and
+// "run_function_locally". Their keys are free-form, so the schemas can't tell they are secrets.
+//
+//nolint:gochecknoglobals
+var defaultRedactPaths = []string{
+	"$.headers.Authorization",
+	"$.headers.Proxy-Authorization",
+	"$.headers.X-Auth-Token",
+	"$.headers.X-Api-Key",
+	"$.headers.Cookie",
+	"$.headers.Set-Cookie",
+}
+
+// createRedactor masks the secret fields of the tool inputs and results, as well as the extra paths
+// provided by the user, so that secrets never end up in the logs.
+func createRedactor(extraPaths []string) (*slogredact.Redactor, error) {
+	inputSchemas, err := scaleway.InputSchemas()
+	if err != nil {
+		return nil, fmt.Errorf("getting tool input schemas: %w", err)
+	}
+
+	outputSchemas, err := scaleway.OutputSchemas()
+	if err != nil {
+		return nil, fmt.Errorf("getting tool output schemas: %w", err)
+	}
+
+	paths := slices.Concat(defaultRedactPaths, extraPaths)
+
+	for _, schemas := range []map[string]*jsonschema.Schema{inputSchemas, outputSchemas} {
+		for _, schema := range schemas {
+			paths = append(paths, slogredact.PathsFromSchema(schema)...)
 		}
+	}
+
+	return slogredact.New(paths...), nil
+}
 
-		xdgStateDir = homeDir + "/.local/state"
+func createLogger(
+	logLevel slog.Level,
+	transport string,
+	redactor *slogredact.Redactor,
+) (*slog.Logger, error) {
+	logDir, err := xdg.StateDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting state directory: %w", err)
 	}
 
-	logDir := xdgStateDir + "/" + constants.ProjectName
 	if err := os.MkdirAll(logDir, 0o750); err != nil {
 		return nil, fmt.Errorf("creating log directory %q: %w", logDir, err)
 	}
@@ -215,22 +374,52 @@ func createLogger(logLevel slog.Level, transport string) (*slog.Logger, error) {
 
 	var handlers []slog.Handler
 
-	// When running in sse, we also log to stderr, so that the user can see
+	// When running over HTTP, we also log to stderr, so that the user can see
 	// errors and warnings directly in their terminal.
-	if transport == sseTransport {
+	if transport != stdioTransport {
 		handlers = append(handlers, tint.NewHandler(os.Stderr, &tint.Options{
-			Level:      logLevel,
-			TimeFormat: time.Kitchen,
+			Level:       logLevel,
+			TimeFormat:  time.Kitchen,
+			ReplaceAttr: redactor.ReplaceAttr,
 		}))
 	}
 
 	handlers = append(handlers, slog.NewJSONHandler(logFile, &slog.HandlerOptions{
-		Level: logLevel,
+		Level:       logLevel,
+		ReplaceAttr: redactor.ReplaceAttr,
 	}))
 
 	return slog.New(slogmulti.Fanout(handlers...)), nil
 }
 
+// newScalewayClient creates the Scaleway client from the profile.
+func newScalewayClient(cliCtx *cliContext, profileName string) (*scw.Client, error) {
+	scwlogger.SetLogger(scwslog.NewLogger(cliCtx.Logger))
+
+	if cliCtx.Debug {
+		scwlogger.EnableDebugMode()
+	}
+
+	p, err := loadScalewayProfile(profileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading Scaleway profile: %w", err)
+	}
+
+	if p.DefaultProjectID == nil {
+		cliCtx.Logger.Warn("No default project ID set in Scaleway profile; some operations may fail.")
+	}
+
+	scwClient, err := scw.NewClient(
+		scw.WithProfile(p),
+		scw.WithUserAgent(constants.UserAgent),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating Scaleway client: %w", err)
+	}
+
+	return scwClient, nil
+}
+
 func loadScalewayProfile(profileName string) (*scw.Profile, error) {
 	cfg, err := scw.LoadConfig()
 	if err != nil {
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRedactor(t *testing.T) {
+	t.Parallel()
+
+	redactor, err := createRedactor(nil)
+	require.NoError(t, err)
+
+	got := redactor.RedactJSON([]byte(
+		`{"function_name":"my-function","headers":{"authorization":"Bearer xyz","X-Auth-Token":"abc","Accept":"*/*"}}`,
+	))
+
+	assert.JSONEq(t,
+		`{"function_name":"my-function","headers":{"authorization":"[REDACTED]","X-Auth-Token":"[REDACTED]","Accept":"*/*"}}`,
+		string(got),
+	)
+}
+
+func TestServeCmd_Validate(t *testing.T) {
+	t.Parallel()
+
+	oauth := authFlags{
+		AuthorizationServers: []string{"https://auth.example.com"},
+		IntrospectionURL:     "https://auth.example.com/oauth2/introspect",
+	}
+
+	withResourceURL := oauth
+	withResourceURL.ResourceURL = "https://mcp.example.com"
+
+	tt := []struct {
+		name      string
+		cmd       serveCmd
+		wantError error
+	}{
+		{
+			name: "OAuth on a loopback host",
+			cmd:  serveCmd{Transport: streamableHTTPTransport, HTTPHost: "127.0.0.1", Auth: oauth},
+		},
+		{
+			name:      "OAuth on a public host without resource URL",
+			cmd:       serveCmd{Transport: streamableHTTPTransport, HTTPHost: "0.0.0.0", Auth: oauth},
+			wantError: errMissingAuthResourceURL,
+		},
+		{
+			name: "OAuth on a public host with resource URL",
+			cmd:  serveCmd{Transport: streamableHTTPTransport, HTTPHost: "0.0.0.0", Auth: withResourceURL},
+		},
+		{
+			name: "static tokens on a public host",
+			cmd: serveCmd{
+				Transport: streamableHTTPTransport,
+				HTTPHost:  "0.0.0.0",
+				Auth:      authFlags{Tokens: []string{"some-token"}},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.ErrorIs(t, tc.cmd.Validate(), tc.wantError)
+		})
+	}
+}
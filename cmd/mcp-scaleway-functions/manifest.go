@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+)
+
+type manifestFlags struct {
+	Profile string `help:"Scaleway profile to use (overrides the active profile)." short:"p"`
+
+	Manifest string `arg:"" default:"scaleway-functions.yaml" help:"Path to the manifest." optional:"" type:"existingfile"`
+}
+
+type planCmd struct {
+	manifestFlags
+}
+
+type applyCmd struct {
+	manifestFlags
+}
+
+func (f manifestFlags) newTools(cliCtx *cliContext) (*scaleway.Tools, error) {
+	scwClient, err := newScalewayClient(cliCtx, f.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return scaleway.NewTools(scwClient), nil
+}
+
+func (cmd *planCmd) Run(cliCtx *cliContext) error {
+	tools, err := cmd.newTools(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	_, plan, err := tools.PlanManifest(slogctx.Inject(ctx, cliCtx.Logger), nil, scaleway.PlanManifestRequest{
+		ManifestPath: cmd.Manifest,
+	})
+	if err != nil {
+		return fmt.Errorf("planning manifest: %w", err)
+	}
+
+	printManifestChanges(os.Stdout, plan.Changes)
+
+	return nil
+}
+
+func (cmd *applyCmd) Run(cliCtx *cliContext) error {
+	tools, err := cmd.newTools(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	_, resp, err := tools.ApplyManifest(slogctx.Inject(ctx, cliCtx.Logger), nil, scaleway.ApplyManifestRequest{
+		ManifestPath: cmd.Manifest,
+	})
+	if err != nil {
+		return fmt.Errorf("applying manifest: %w", err)
+	}
+
+	printManifestChanges(os.Stdout, resp.Changes)
+
+	for _, deployment := range resp.Deployments {
+		if deployment.ErrorMessage == "" {
+			continue
+		}
+
+		cliCtx.Logger.Error("Function is in error", "function_name", deployment.Name, "error", deployment.ErrorMessage)
+
+		for _, log := range deployment.BuildLogs {
+			fmt.Fprintln(os.Stderr, log.Message)
+		}
+	}
+
+	return nil
+}
+
+func printManifestChanges(w io.Writer, changes []scaleway.ManifestChange) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes.")
+
+		return
+	}
+
+	symbols := map[string]string{
+		scaleway.ManifestActionCreate: "+",
+		scaleway.ManifestActionUpdate: "~",
+		scaleway.ManifestActionDelete: "-",
+	}
+
+	for _, change := range changes {
+		line := fmt.Sprintf("%s %s %s %s", symbols[change.Action], change.Action, change.Resource, change.Name)
+		if len(change.Fields) > 0 {
+			line += " (" + strings.Join(change.Fields, ", ") + ")"
+		}
+
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type watchCmd struct {
+	Profile string `help:"Scaleway profile to use (overrides the active profile)." short:"p"`
+
+	Directory    string        `arg:"" help:"Directory where the function code is located." type:"existingdir"`
+	FunctionName string        `help:"Name of the function to redeploy." required:"" short:"f"`
+	Debounce     time.Duration `help:"Wait for the files to stop changing for this long before redeploying." default:"500ms"`
+
+	ProjectID string `help:"ID of the Scaleway project of the function (defaults to all the projects)."`
+	Region    string `help:"Scaleway region of the function (defaults to the one of the active profile)."`
+}
+
+func (cmd *watchCmd) Run(cliCtx *cliContext) error {
+	logger := cliCtx.Logger.With("function_name", cmd.FunctionName)
+
+	scwClient, err := newScalewayClient(cliCtx, cmd.Profile)
+	if err != nil {
+		return err
+	}
+
+	tools := scaleway.NewTools(scwClient)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	ctx = slogctx.Inject(ctx, logger)
+
+	logger.Info("Watching for changes...", "directory", cmd.Directory)
+
+	// The build logs printed while building, which are not printed again on failure.
+	printedBuildLogs := make(map[printedBuildLog]struct{})
+
+	err = tools.WatchFunction(ctx, scaleway.WatchFunctionOptions{
+		Scope: scaleway.Scope{
+			ProjectID: cmd.ProjectID,
+			Region:    scw.Region(cmd.Region),
+		},
+		Directory:    cmd.Directory,
+		FunctionName: cmd.FunctionName,
+		Debounce:     cmd.Debounce,
+		OnProgress: func(message string) {
+			fmt.Fprintln(os.Stderr, message)
+		},
+		// Build logs are printed as is, to be readable in the terminal.
+		OnBuildLog: func(log cockpit.Log) {
+			printedBuildLogs[newPrintedBuildLog(log)] = struct{}{}
+
+			fmt.Fprintln(os.Stderr, log.Message)
+		},
+		OnDeployment: func(deployment scaleway.FunctionDeployment, err error) {
+			defer clear(printedBuildLogs)
+
+			if err != nil {
+				logger.Error("Deployment failed", "error", err)
+
+				return
+			}
+
+			if deployment.Status == function.FunctionStatusError.String() {
+				logger.Error("Function is in error", "status", deployment.Status, "error", deployment.ErrorMessage)
+
+				for _, log := range deployment.BuildLogs {
+					if _, printed := printedBuildLogs[newPrintedBuildLog(log)]; !printed {
+						fmt.Fprintln(os.Stderr, log.Message)
+					}
+				}
+
+				return
+			}
+
+			logger.Info("Function deployed", "status", deployment.Status, "endpoint", deployment.Endpoint)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("watching function: %w", err)
+	}
+
+	return nil
+}
+
+// printedBuildLog identifies a build log printed to the terminal.
+type printedBuildLog struct {
+	timestamp int64
+	message   string
+}
+
+func newPrintedBuildLog(log cockpit.Log) printedBuildLog {
+	return printedBuildLog{timestamp: log.Timestamp.UnixNano(), message: log.Message}
+}
@@ -0,0 +1,28 @@
+// Package filelock locks files across processes, as several instances of the server
+// may share the same state directory (e.g. one per MCP client).
+package filelock
+
+import (
+	"fmt"
+	"os"
+)
+
+// Lock blocks until it holds the lock file at path, creating it if needed, and returns
+// the function releasing it. The operating system releases the lock if the process exits.
+func Lock(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening lock file: %w", err)
+	}
+
+	if err := lock(f); err != nil {
+		_ = f.Close()
+
+		return nil, fmt.Errorf("locking file: %w", err)
+	}
+
+	return func() {
+		_ = unlock(f)
+		_ = f.Close()
+	}, nil
+}
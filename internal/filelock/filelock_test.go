@@ -0,0 +1,44 @@
+package filelock
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	unlock, err := Lock(path)
+	require.NoError(t, err)
+
+	locked := make(chan func())
+
+	go func() {
+		// Each call opens the file again, so it waits like another process would.
+		unlockAgain, err := Lock(path)
+		assert.NoError(t, err)
+
+		locked <- unlockAgain
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("lock acquired while already held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+
+	select {
+	case unlockAgain := <-locked:
+		unlockAgain()
+	case <-time.After(5 * time.Second):
+		t.Fatal("lock not acquired once released")
+	}
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package filelock
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func lock(f *os.File) error {
+	//nolint:wrapcheck // wrapped by the caller.
+	return unix.Flock(int(f.Fd()), unix.LOCK_EX)
+}
+
+func unlock(f *os.File) error {
+	//nolint:wrapcheck // wrapped by the caller.
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
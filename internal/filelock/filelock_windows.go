@@ -0,0 +1,27 @@
+//go:build windows
+
+package filelock
+
+import (
+	"math"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+func lock(f *os.File) error {
+	//nolint:wrapcheck // wrapped by the caller.
+	return windows.LockFileEx(
+		windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK,
+		0,
+		math.MaxUint32,
+		math.MaxUint32,
+		new(windows.Overlapped),
+	)
+}
+
+func unlock(f *os.File) error {
+	//nolint:wrapcheck // wrapped by the caller.
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+}
@@ -0,0 +1,333 @@
+package httpauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/oauthex"
+)
+
+// protectedResourceMetadataPath is the well-known path of the OAuth 2.0 protected resource
+// metadata document. Reference: https://www.rfc-editor.org/rfc/rfc9728.html#section-3
+const protectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+// staticTokenValidity is the expiration given to static tokens. It only needs to be in
+// the future, as tokens are verified again on every request.
+const staticTokenValidity = time.Minute
+
+// introspectionCacheTTL bounds how long an introspected token is trusted without asking the
+// authorization server again, so that revoked tokens are rejected shortly after.
+const introspectionCacheTTL = time.Minute
+
+var (
+	ErrMissingResourceURL          = errors.New("resource URL is required when authentication is enabled")
+	ErrMissingAuthorizationServers = errors.New(
+		"at least one authorization server is required when token introspection is enabled",
+	)
+	ErrIntrospectionFailed = errors.New("token introspection failed")
+
+	// errTokenNotVerified is returned to the client when the authorization server could not be
+	// reached. The details are only logged, as they would leak the introspection endpoint.
+	errTokenNotVerified = fmt.Errorf("%w: token could not be verified", auth.ErrInvalidToken)
+)
+
+// Config configures authentication in front of the HTTP transports.
+// Static bearer tokens and OAuth 2.1 access tokens can be used together.
+type Config struct {
+	// Tokens are static bearer tokens accepted by the server.
+	Tokens []string
+
+	// ResourceURL is the canonical URL of this MCP server. It is advertised in the
+	// protected resource metadata, and OAuth access tokens must be issued for it.
+	ResourceURL string
+
+	// AuthorizationServers are the issuers of OAuth access tokens for this server.
+	AuthorizationServers []string
+	// IntrospectionURL is the OAuth 2.0 token introspection endpoint (RFC 7662)
+	// used to validate access tokens.
+	IntrospectionURL string
+	ClientID         string
+	ClientSecret     string
+	// Scopes are the scopes required on OAuth access tokens.
+	Scopes []string
+}
+
+// Enabled reports whether any authentication method is configured.
+func (c Config) Enabled() bool {
+	return slices.ContainsFunc(c.Tokens, func(t string) bool { return t != "" }) ||
+		c.IntrospectionURL != ""
+}
+
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.ResourceURL == "" {
+		return ErrMissingResourceURL
+	}
+
+	if _, err := url.Parse(c.ResourceURL); err != nil {
+		return fmt.Errorf("parsing resource URL: %w", err)
+	}
+
+	if c.IntrospectionURL != "" && len(c.AuthorizationServers) == 0 {
+		return ErrMissingAuthorizationServers
+	}
+
+	return nil
+}
+
+// Wrap returns a handler which serves the protected resource metadata document, and requires
+// a valid bearer token on every other request before passing it to next.
+// Requests without a valid token get a 401 response with a WWW-Authenticate header pointing
+// to the protected resource metadata.
+func Wrap(cfg Config, next http.Handler) (http.Handler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	metadataURL, err := protectedResourceMetadataURL(cfg.ResourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &verifier{
+		// Guard against empty tokens, e.g. from an empty environment variable.
+		tokens:     slices.DeleteFunc(slices.Clone(cfg.Tokens), func(t string) bool { return t == "" }),
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[[sha256.Size]byte]cachedToken),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metadataURL.Path, protectedResourceMetadataHandler(cfg))
+	mux.Handle("/", auth.RequireBearerToken(v.Verify, &auth.RequireBearerTokenOptions{
+		ResourceMetadataURL: metadataURL.String(),
+		Scopes:              cfg.Scopes,
+	})(next))
+
+	return mux, nil
+}
+
+// protectedResourceMetadataURL inserts the well-known path between the host and the path
+// of the resource URL, as mandated by RFC 9728.
+func protectedResourceMetadataURL(resourceURL string) (*url.URL, error) {
+	u, err := url.Parse(resourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing resource URL: %w", err)
+	}
+
+	u.Path = protectedResourceMetadataPath + strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+
+	return u, nil
+}
+
+func protectedResourceMetadataHandler(cfg Config) http.Handler {
+	metadata := oauthex.ProtectedResourceMetadata{
+		Resource:               cfg.ResourceURL,
+		AuthorizationServers:   cfg.AuthorizationServers,
+		ScopesSupported:        cfg.Scopes,
+		BearerMethodsSupported: []string{"header"},
+		ResourceName:           constants.ProjectName,
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_ = json.NewEncoder(w).Encode(metadata)
+	})
+}
+
+type verifier struct {
+	tokens     []string
+	cfg        Config
+	httpClient *http.Client
+
+	// cache holds the active introspected tokens, keyed by their hash.
+	cacheMu sync.Mutex
+	cache   map[[sha256.Size]byte]cachedToken
+}
+
+type cachedToken struct {
+	info *auth.TokenInfo
+	// until is the earliest of the token expiration and the end of the cache TTL.
+	until time.Time
+}
+
+// Verify implements auth.TokenVerifier.
+func (v *verifier) Verify(ctx context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+	for _, t := range v.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return &auth.TokenInfo{
+				// Static tokens grant every scope.
+				Scopes:     v.cfg.Scopes,
+				Expiration: time.Now().Add(staticTokenValidity),
+			}, nil
+		}
+	}
+
+	if v.cfg.IntrospectionURL == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	key := sha256.Sum256([]byte(token))
+	if info := v.cached(key); info != nil {
+		return info, nil
+	}
+
+	info, err := v.introspect(ctx, token)
+
+	switch {
+	case errors.Is(err, auth.ErrInvalidToken):
+		return nil, err
+	case err != nil:
+		slogctx.FromContext(ctx).ErrorContext(ctx, "Token introspection failed", "error", err)
+
+		return nil, errTokenNotVerified
+	}
+
+	v.store(key, info)
+
+	return info, nil
+}
+
+// cached returns the cached information of the token, if it is still valid.
+func (v *verifier) cached(key [sha256.Size]byte) *auth.TokenInfo {
+	v.cacheMu.Lock()
+	defer v.cacheMu.Unlock()
+
+	cached, ok := v.cache[key]
+	if !ok || !time.Now().Before(cached.until) {
+		return nil
+	}
+
+	return cached.info
+}
+
+func (v *verifier) store(key [sha256.Size]byte, info *auth.TokenInfo) {
+	v.cacheMu.Lock()
+	defer v.cacheMu.Unlock()
+
+	now := time.Now()
+
+	// Expired entries are pruned on insertion, so the cache does not grow with every token seen.
+	for k, cached := range v.cache {
+		if !now.Before(cached.until) {
+			delete(v.cache, k)
+		}
+	}
+
+	until := now.Add(introspectionCacheTTL)
+	if info.Expiration.Before(until) {
+		until = info.Expiration
+	}
+
+	v.cache[key] = cachedToken{info: info, until: until}
+}
+
+// introspectionResponse is the response of an OAuth 2.0 token introspection endpoint.
+// Reference: https://www.rfc-editor.org/rfc/rfc7662.html#section-2.2
+type introspectionResponse struct {
+	Active   bool            `json:"active"`
+	Scope    string          `json:"scope"`
+	Exp      int64           `json:"exp"`
+	Audience json.RawMessage `json:"aud"`
+}
+
+func (v *verifier) introspect(ctx context.Context, token string) (*auth.TokenInfo, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		v.cfg.IntrospectionURL,
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating introspection request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if v.cfg.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(v.cfg.ClientID), url.QueryEscape(v.cfg.ClientSecret))
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("performing introspection request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status code %d", ErrIntrospectionFailed, resp.StatusCode)
+	}
+
+	var introspection introspectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&introspection); err != nil {
+		return nil, fmt.Errorf("decoding introspection response: %w", err)
+	}
+
+	if !introspection.Active {
+		return nil, fmt.Errorf("%w: token is not active", auth.ErrInvalidToken)
+	}
+
+	// Tokens must have been issued for this server specifically, otherwise a token
+	// meant for another resource could be replayed against us.
+	if !slices.Contains(parseAudience(introspection.Audience), v.cfg.ResourceURL) {
+		return nil, fmt.Errorf("%w: token audience does not match resource", auth.ErrInvalidToken)
+	}
+
+	expiration := time.Unix(introspection.Exp, 0)
+	if introspection.Exp == 0 {
+		// The "exp" claim is optional, but the token is introspected again once its cache entry expires.
+		expiration = time.Now().Add(staticTokenValidity)
+	}
+
+	return &auth.TokenInfo{
+		Scopes:     strings.Fields(introspection.Scope),
+		Expiration: expiration,
+	}, nil
+}
+
+// parseAudience parses the "aud" claim, which can either be a single string or an array of strings.
+func parseAudience(raw json.RawMessage) []string {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(raw, &multiple); err == nil {
+		return multiple
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package httpauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	someResourceURL = "https://mcp.example.com"
+	someStaticToken = "some-static-token"
+	someOAuthToken  = "some-oauth-token"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	introspectionServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientID, clientSecret, _ := r.BasicAuth()
+			if clientID != "my-client" || clientSecret != "my-secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			resp := map[string]any{"active": false}
+
+			switch r.FormValue("token") {
+			case someOAuthToken:
+				resp = map[string]any{
+					"active": true,
+					"scope":  "functions:read functions:write",
+					"exp":    time.Now().Add(time.Hour).Unix(),
+					"aud":    []string{someResourceURL},
+				}
+			case "token-for-another-resource":
+				resp = map[string]any{
+					"active": true,
+					"scope":  "functions:read functions:write",
+					"exp":    time.Now().Add(time.Hour).Unix(),
+					"aud":    "https://other.example.com",
+				}
+			}
+
+			_ = json.NewEncoder(w).Encode(resp)
+		}),
+	)
+	t.Cleanup(introspectionServer.Close)
+
+	handler, err := Wrap(Config{
+		Tokens:               []string{someStaticToken},
+		ResourceURL:          someResourceURL,
+		AuthorizationServers: []string{"https://auth.example.com"},
+		IntrospectionURL:     introspectionServer.URL,
+		ClientID:             "my-client",
+		ClientSecret:         "my-secret",
+		Scopes:               []string{"functions:write"},
+	}, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	require.NoError(t, err)
+
+	tt := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{
+			name:       "missing token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown token",
+			token:      "not-a-valid-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "static token",
+			token:      someStaticToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "oauth token",
+			token:      someOAuthToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "oauth token issued for another resource",
+			token:      "token-for-another-resource",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+
+			if tc.wantStatus == http.StatusUnauthorized {
+				assert.Equal(
+					t,
+					"Bearer resource_metadata="+someResourceURL+protectedResourceMetadataPath,
+					rec.Header().Get("WWW-Authenticate"),
+				)
+			}
+		})
+	}
+
+	t.Run("protected resource metadata", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, protectedResourceMetadataPath, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var got map[string]any
+
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+		assert.Equal(t, someResourceURL, got["resource"])
+		assert.Equal(t, []any{"https://auth.example.com"}, got["authorization_servers"])
+	})
+}
+
+func TestWrap_introspection(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		status     int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{
+			name:       "active tokens are cached",
+			status:     http.StatusOK,
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "authorization server error is not leaked",
+			status:     http.StatusBadGateway,
+			wantStatus: http.StatusUnauthorized,
+			wantCalls:  2,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			introspectionServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					calls.Add(1)
+
+					w.WriteHeader(tc.status)
+
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"active": true,
+						"exp":    time.Now().Add(time.Hour).Unix(),
+						"aud":    someResourceURL,
+					})
+				}),
+			)
+			t.Cleanup(introspectionServer.Close)
+
+			handler, err := Wrap(Config{
+				ResourceURL:          someResourceURL,
+				AuthorizationServers: []string{"https://auth.example.com"},
+				IntrospectionURL:     introspectionServer.URL,
+			}, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			require.NoError(t, err)
+
+			for range 2 {
+				req := httptest.NewRequest(http.MethodPost, "/", nil)
+				req.Header.Set("Authorization", "Bearer "+someOAuthToken)
+
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+
+				assert.Equal(t, tc.wantStatus, rec.Code)
+				assert.NotContains(t, rec.Body.String(), introspectionServer.URL)
+			}
+
+			assert.Equal(t, tc.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		cfg       Config
+		wantError assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "disabled",
+			cfg:       Config{},
+			wantError: assert.NoError,
+		},
+		{
+			name: "introspection without authorization servers",
+			cfg: Config{
+				ResourceURL:      someResourceURL,
+				IntrospectionURL: "https://auth.example.com/introspect",
+			},
+			wantError: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrMissingAuthorizationServers)
+			},
+		},
+		{
+			name: "missing resource URL",
+			cfg: Config{
+				Tokens: []string{someStaticToken},
+			},
+			wantError: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrMissingResourceURL)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.wantError(t, tc.cfg.Validate())
+		})
+	}
+}
@@ -1,18 +1,23 @@
 package scaleway
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
-	"github.com/cyclimse/mcp-scaleway-functions/internal/std"
 	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/jsonstream"
 	"github.com/moby/moby/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
@@ -20,6 +25,9 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// containerLogTailLines is the number of output lines reported when a container fails.
+const containerLogTailLines = 20
+
 var (
 	ErrRuntimeNotFound               = errors.New("runtime not found")
 	ErrRuntimeDependencyNotSupported = errors.New(
@@ -29,6 +37,8 @@ var (
 	ErrDependencyInstallFailed = errors.New(
 		"failed to install dependency",
 	)
+	ErrUnsupportedDependencyFile = errors.New("unsupported dependency file")
+	ErrImagePullFailed           = errors.New("failed to pull image")
 
 	//nolint:gochecknoglobals
 	supportedLanguagesForDependencies = []string{
@@ -43,7 +53,15 @@ var addDependencyTool = &mcp.Tool{
 	Description: `Add a native dependency to a Scaleway Function.
 	This uses a alpine-based Docker container to install the dependency in the function directory.
 	The "package" argument is the name of the package to add, for example "pydantic" for Python or "sharp" for Node.js.
-	
+
+	To install all the dependencies of the function at once, set "from_file" instead of "package".
+	It's the path of a dependency file, relative to the function directory:
+	  - Python: a requirements file such as "requirements.txt" ("pip install -r"),
+	    or "pyproject.toml" (the project itself is installed along with its dependencies).
+	  - Node.js: "package.json" ("npm install"), or a lockfile such as "package-lock.json" ("npm ci").
+	    The file must be at the root of the function directory.
+	Installing from a file is preferred when the function has many dependencies, as versions stay consistent.
+
 	Note that for non-native dependencies you can (and may favor) add them through:
 	  - Python: "pip install <package> --target ./<function_directory>/package"
 	  - Node.js: "npm install <package> --prefix ./<function_directory>"
@@ -52,20 +70,79 @@ var addDependencyTool = &mcp.Tool{
 }
 
 type AddDependencyRequest struct {
+	Scope
+
 	Directory string `json:"directory"`
 	Runtime   string `json:"runtime"`
-	Package   string `json:"package"`
+	Package   string `json:"package,omitempty"`
+	FromFile  string `json:"from_file,omitempty"`
+}
+
+// validate checks that exactly one of the package or the dependency file is set,
+// and that the dependency file is inside the function directory.
+func (req AddDependencyRequest) validate() error {
+	if (req.Package == "") == (req.FromFile == "") {
+		return fmt.Errorf("%w: exactly one of \"package\" or \"from_file\" must be set", ErrInvalidValue)
+	}
+
+	if req.FromFile == "" {
+		return nil
+	}
+
+	if !filepath.IsLocal(req.FromFile) {
+		return fmt.Errorf("%w: %q is not inside the function directory", ErrInvalidValue, req.FromFile)
+	}
+
+	if _, err := os.Stat(filepath.Join(req.Directory, req.FromFile)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	return nil
+}
+
+// pipInstallArgs returns the arguments of "pip install", without the target folder.
+func (req AddDependencyRequest) pipInstallArgs() []string {
+	switch {
+	case req.Package != "":
+		return []string{req.Package}
+	case filepath.Base(req.FromFile) == "pyproject.toml":
+		return []string{path.Join("/function", path.Dir(filepath.ToSlash(req.FromFile)))}
+	default:
+		return []string{"-r", path.Join("/function", filepath.ToSlash(req.FromFile))}
+	}
+}
+
+// npmArgs returns the npm command to run, without the prefix folder.
+func (req AddDependencyRequest) npmArgs() ([]string, error) {
+	if req.Package != "" {
+		return []string{"install", req.Package}, nil
+	}
+
+	// npm only looks for the package files in the prefix folder.
+	if filepath.Dir(req.FromFile) != "." {
+		return nil, fmt.Errorf("%w: %q must be at the root of the function directory",
+			ErrUnsupportedDependencyFile, req.FromFile)
+	}
+
+	switch req.FromFile {
+	case "package.json":
+		return []string{"install"}, nil
+	case "package-lock.json", "npm-shrinkwrap.json":
+		return []string{"ci"}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDependencyFile, req.FromFile)
+	}
 }
 
 type AddDependencyResponse struct{}
 
 func (t *Tools) AddDependency(
 	ctx context.Context,
-	_ *mcp.CallToolRequest,
+	req *mcp.CallToolRequest,
 	in AddDependencyRequest,
 ) (*mcp.CallToolResult, AddDependencyResponse, error) {
 	// Check that the runtime exists and supports dependencies
-	runtime, language, err := getAndValidateRuntime(ctx, t.functionsAPI, in)
+	runtime, language, err := getAndValidateRuntime(ctx, t.functionsAPI, in.Region, in.Runtime)
 	if err != nil {
 		return nil, AddDependencyResponse{}, fmt.Errorf("getting runtime: %w", err)
 	}
@@ -79,6 +156,10 @@ func (t *Tools) AddDependency(
 		)
 	}
 
+	if err := in.validate(); err != nil {
+		return nil, AddDependencyResponse{}, err
+	}
+
 	// Initialize Docker client if needed
 	err = t.loadDockerClient()
 	if err != nil {
@@ -97,12 +178,17 @@ func (t *Tools) AddDependency(
 			return nil, AddDependencyResponse{}, fmt.Errorf("creating package folder: %w", err)
 		}
 
-		containerConfig, hostConfig = getPythonContainerConfigs(runtime, in.Directory, in.Package)
+		containerConfig, hostConfig = getPythonContainerConfigs(runtime, in.Directory, in.pipInstallArgs())
 	case "node":
+		npmArgs, err := in.npmArgs()
+		if err != nil {
+			return nil, AddDependencyResponse{}, err
+		}
+
 		containerConfig, hostConfig = getNodeContainerConfigs(
 			runtime,
 			in.Directory,
-			in.Package,
+			npmArgs,
 		)
 	default:
 		return nil, AddDependencyResponse{}, fmt.Errorf(
@@ -112,7 +198,17 @@ func (t *Tools) AddDependency(
 		)
 	}
 
-	if err := runContainer(ctx, t.dockerAPI, containerConfig, hostConfig); err != nil {
+	progress := NewDockerProgress(containerConfig.Image)
+
+	err = runContainer(
+		ctx,
+		t.dockerAPI,
+		containerConfig,
+		hostConfig,
+		progress.GetImagePullCB(ctx, req),
+		progress.GetContainerOutputCB(ctx, req),
+	)
+	if err != nil {
 		return nil, AddDependencyResponse{}, fmt.Errorf("running container: %w", err)
 	}
 
@@ -122,10 +218,32 @@ func (t *Tools) AddDependency(
 func getAndValidateRuntime(
 	ctx context.Context,
 	functionsAPI FunctionAPI,
-	in AddDependencyRequest,
+	region scw.Region,
+	runtimeName string,
+) (*function.Runtime, string, error) {
+	runtime, language, err := getRuntimeByName(ctx, functionsAPI, region, runtimeName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !slices.Contains(supportedLanguagesForDependencies, language) {
+		return nil, "", fmt.Errorf("%w: %s", ErrRuntimeDependencyNotSupported, runtimeName)
+	}
+
+	return runtime, language, nil
+}
+
+// getRuntimeByName returns the runtime and its language, in lower case.
+func getRuntimeByName(
+	ctx context.Context,
+	functionsAPI FunctionAPI,
+	region scw.Region,
+	runtimeName string,
 ) (*function.Runtime, string, error) {
 	runtimes, err := functionsAPI.ListFunctionRuntimes(
-		&function.ListFunctionRuntimesRequest{},
+		&function.ListFunctionRuntimesRequest{
+			Region: region,
+		},
 		scw.WithContext(ctx),
 	)
 	if err != nil {
@@ -133,36 +251,30 @@ func getAndValidateRuntime(
 	}
 
 	i := slices.IndexFunc(runtimes.Runtimes, func(r *function.Runtime) bool {
-		return r.Name == in.Runtime
+		return r.Name == runtimeName
 	})
 	if i == -1 {
-		return nil, "", fmt.Errorf("%w: %s", ErrRuntimeNotFound, in.Runtime)
+		return nil, "", fmt.Errorf("%w: %s", ErrRuntimeNotFound, runtimeName)
 	}
 
 	runtime := runtimes.Runtimes[i]
-	language := strings.ToLower(runtime.Language)
 
-	if !slices.Contains(supportedLanguagesForDependencies, language) {
-		return nil, "", fmt.Errorf("%w: %s", ErrRuntimeDependencyNotSupported, in.Runtime)
-	}
-
-	return runtime, language, nil
+	return runtime, strings.ToLower(runtime.Language), nil
 }
 
 // Reference: https://www.scaleway.com/en/docs/serverless-functions/how-to/package-function-dependencies-in-zip/?tab=python-2
 func getPythonContainerConfigs(
 	runtime *function.Runtime,
-	directory, pkg string,
+	directory string,
+	installArgs []string,
 ) (*container.Config, *container.HostConfig) {
 	return &container.Config{
 			Image: constants.PublicRuntimesRegistry + "/python-dep:" + runtime.Version,
-			Cmd: []string{
-				"pip",
-				"install",
-				pkg,
-				"--target",
-				"/function/" + constants.PythonPackageFolder,
-			},
+			Cmd: slices.Concat(
+				[]string{"pip", "install"},
+				installArgs,
+				[]string{"--target", "/function/" + constants.PythonPackageFolder},
+			),
 			Env: []string{
 				"PYTHONUNBUFFERED=1",
 			},
@@ -177,27 +289,14 @@ func getPythonContainerConfigs(
 
 func getNodeContainerConfigs(
 	runtime *function.Runtime,
-	directory, pkg string,
+	directory string,
+	npmArgs []string,
 ) (*container.Config, *container.HostConfig) {
 	// Strangely enough, we don't provide a Scaleway-specific image for Node.js dependencies
 	// like we do for Python. So we just use the public Node.js Alpine-based image from Docker Hub.
-	versionParts := strings.SplitN(runtime.Version, ".", 2)
-	if len(versionParts) == 0 {
-		versionParts = []string{runtime.Version}
-	}
-
-	majorVersion := versionParts[0]
-	image := "node:" + majorVersion + "-alpine"
-
 	return &container.Config{
-			Image: image,
-			Cmd: []string{
-				"npm",
-				"install",
-				pkg,
-				"--prefix",
-				"/function",
-			},
+			Image: nodeImage(runtime),
+			Cmd:   slices.Concat([]string{"npm"}, npmArgs, []string{"--prefix", "/function"}),
 			Env: []string{
 				// Do not install dev dependencies!
 				"NODE_ENV=production",
@@ -211,32 +310,81 @@ func getNodeContainerConfigs(
 		}
 }
 
-func runContainer(
-	ctx context.Context,
-	dockerClient client.APIClient,
-	containerConfig *container.Config,
-	hostConfig *container.HostConfig,
-) error {
-	logger := slogctx.FromContext(ctx)
-	logger.Info("Pulling Docker image", "image", containerConfig.Image)
+func nodeImage(runtime *function.Runtime) string {
+	versionParts := strings.SplitN(runtime.Version, ".", 2)
+	if len(versionParts) == 0 {
+		versionParts = []string{runtime.Version}
+	}
+
+	majorVersion := versionParts[0]
+
+	return "node:" + majorVersion + "-alpine"
+}
 
-	// LATER: this is really slow: we should send progress updates to the user
-	// when pulling the image and running the container
-	reader, err := dockerClient.ImagePull(ctx, containerConfig.Image, client.ImagePullOptions{})
+// imagePullMessage is a message of the JSON stream returned by the image pull endpoint.
+type imagePullMessage struct {
+	ID       string               `json:"id,omitempty"`
+	Status   string               `json:"status,omitempty"`
+	Progress *jsonstream.Progress `json:"progressDetail,omitempty"`
+	Error    *jsonstream.Error    `json:"errorDetail,omitempty"`
+}
+
+func pullImage(ctx context.Context, dockerClient client.APIClient, image string, cb ImagePullCallback) error {
+	slogctx.FromContext(ctx).Info("Pulling Docker image", "image", image)
+
+	reader, err := dockerClient.ImagePull(ctx, image, client.ImagePullOptions{})
 	if err != nil {
-		return fmt.Errorf("pulling image %s: %w", containerConfig.Image, err)
+		return fmt.Errorf("pulling image %s: %w", image, err)
 	}
 
 	defer func() {
 		_ = reader.Close()
 	}()
 
-	// We don't need to read the output, just wait for the image to be pulled.
-	err = std.Copy(ctx, io.Discard, reader)
-	if err != nil {
-		return fmt.Errorf("reading image pull response: %w", err)
+	decoder := json.NewDecoder(reader)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("pulling image %s: %w", image, err)
+		}
+
+		var msg imagePullMessage
+
+		err := decoder.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading image pull response: %w", err)
+		}
+
+		// Errors are reported in the stream, after the request succeeded.
+		if msg.Error != nil {
+			return fmt.Errorf("%w: %s: %w", ErrImagePullFailed, image, msg.Error)
+		}
+
+		if cb != nil && msg.Status != "" {
+			cb(msg)
+		}
+	}
+}
+
+//nolint:funlen
+func runContainer(
+	ctx context.Context,
+	dockerClient client.APIClient,
+	containerConfig *container.Config,
+	hostConfig *container.HostConfig,
+	pullCB ImagePullCallback,
+	outputCB ContainerOutputCallback,
+) error {
+	if err := pullImage(ctx, dockerClient, containerConfig.Image, pullCB); err != nil {
+		return err
 	}
 
+	logger := slogctx.FromContext(ctx)
+
 	containerName := namegenerator.GetRandomName(constants.ProjectName, "dep")
 	logger = logger.With("container_name", containerName)
 	logger.Info("Creating and starting Docker container")
@@ -254,25 +402,122 @@ func runContainer(
 	}
 
 	logger = logger.With("container_id", resp.ID)
+
+	// The container is removed as soon as it exits, so we need to attach to it and to wait for it
+	// before starting it, otherwise its output and exit code could be lost.
+	attach, err := dockerClient.ContainerAttach(ctx, resp.ID, client.ContainerAttachOptions{
+		Stream: true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("attaching to container: %w", err)
+	}
+
+	defer attach.Close()
+
+	statusCh, errCh := dockerClient.ContainerWait(ctx, resp.ID, container.WaitConditionNextExit)
+
 	logger.Info("Starting Docker container")
 
 	if err := dockerClient.ContainerStart(ctx, resp.ID, client.ContainerStartOptions{}); err != nil {
 		return fmt.Errorf("starting container: %w", err)
 	}
 
+	tail := newLogTail(containerLogTailLines, outputCB)
+	outputDone := make(chan struct{})
+
+	go func() {
+		defer close(outputDone)
+
+		stdout, stderr := tail.writer(), tail.writer()
+
+		if _, err := stdcopy.StdCopy(stdout, stderr, attach.Reader); err != nil && ctx.Err() == nil {
+			logger.Warn("Failed to read container output", "error", err)
+		}
+
+		stdout.flush()
+		stderr.flush()
+	}()
+
 	logger.Info("Waiting for build container to finish")
 
-	statusCh, errCh := dockerClient.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
 	select {
 	case err := <-errCh:
 		if err != nil {
 			return fmt.Errorf("waiting for container: %w", err)
 		}
 	case status := <-statusCh:
+		// The output stream ends when the container exits.
+		<-outputDone
+
 		if status.StatusCode != 0 {
-			return fmt.Errorf("%w: exit code %d", ErrDependencyInstallFailed, status.StatusCode)
+			return fmt.Errorf("%w: exit code %d, last output lines:\n%s",
+				ErrDependencyInstallFailed, status.StatusCode, tail.String())
 		}
 	}
 
 	return nil
 }
+
+// logTail keeps the last lines written by a container, to explain why it failed.
+type logTail struct {
+	maxLines int
+	lines    []string
+	onLine   ContainerOutputCallback
+}
+
+func newLogTail(maxLines int, onLine ContainerOutputCallback) *logTail {
+	return &logTail{maxLines: maxLines, onLine: onLine}
+}
+
+func (t *logTail) add(line string) {
+	if len(t.lines) == t.maxLines {
+		t.lines = t.lines[1:]
+	}
+
+	t.lines = append(t.lines, line)
+
+	if t.onLine != nil {
+		t.onLine(line)
+	}
+}
+
+func (t *logTail) String() string {
+	return strings.Join(t.lines, "\n")
+}
+
+// writer returns a writer which splits the output into lines. Stdout and stderr need their
+// own writers, as a line can be written in several chunks.
+func (t *logTail) writer() *lineWriter {
+	return &lineWriter{onLine: t.add}
+}
+
+type lineWriter struct {
+	buf    []byte
+	onLine func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i == -1 {
+			break
+		}
+
+		w.onLine(strings.TrimRight(string(w.buf[:i]), "\r"))
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// flush sends the last line, when the output does not end with a newline.
+func (w *lineWriter) flush() {
+	if len(w.buf) > 0 {
+		w.onLine(string(w.buf))
+		w.buf = nil
+	}
+}
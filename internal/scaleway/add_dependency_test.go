@@ -1,8 +1,12 @@
 package scaleway
 
 import (
+	"bufio"
+	"bytes"
 	"io"
+	"net"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 
@@ -10,7 +14,9 @@ import (
 	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockdocker"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -49,6 +55,53 @@ var listRuntimesResponse = &function.ListFunctionRuntimesResponse{
 	},
 }
 
+// newHijackedResponse returns the multiplexed stream of a container which wrote the output to stderr.
+func newHijackedResponse(t *testing.T, output string) client.HijackedResponse {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if output != "" {
+		_, err := stdcopy.NewStdWriter(&buf, stdcopy.Stderr).Write([]byte(output))
+		require.NoError(t, err)
+	}
+
+	conn, other := net.Pipe()
+	t.Cleanup(func() { _ = other.Close() })
+
+	return client.HijackedResponse{Conn: conn, Reader: bufio.NewReader(&buf)}
+}
+
+func TestPullImage(t *testing.T) {
+	t.Parallel()
+
+	stream := `{"status":"Pulling from library/node","id":"22-alpine"}
+{"status":"Pulling fs layer","id":"abc"}
+{"status":"Downloading","progressDetail":{"current":1,"total":2},"id":"abc"}
+{"status":"Downloading","progressDetail":{"current":2,"total":2},"id":"abc"}
+{"errorDetail":{"message":"no space left on device"},"error":"no space left on device"}
+`
+
+	mockDockerAPI := mockdocker.NewMockAPIClient(t)
+	mockDockerAPI.EXPECT().ImagePull(mock.Anything, "node:22-alpine", mock.Anything).
+		Return(io.NopCloser(strings.NewReader(stream)), nil).Once()
+
+	var got []string
+
+	err := pullImage(t.Context(), mockDockerAPI, "node:22-alpine", func(msg imagePullMessage) {
+		got = append(got, msg.ID+" "+msg.Status)
+	})
+	require.ErrorIs(t, err, ErrImagePullFailed)
+	require.ErrorContains(t, err, "no space left on device")
+
+	assert.Equal(t, []string{
+		"22-alpine Pulling from library/node",
+		"abc Pulling fs layer",
+		"abc Downloading",
+		"abc Downloading",
+	}, got)
+}
+
 func TestTools_AddDependency(t *testing.T) {
 	t.Parallel()
 
@@ -67,9 +120,19 @@ func TestTools_AddDependency(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	for _, file := range []string{
+		myNodeFunctionDir + "/package-lock.json",
+		myNodeFunctionDir + "/yarn.lock",
+		myPythonFunctionDir + "/requirements.txt",
+	} {
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+	}
+
 	tt := []struct {
 		name            string
 		req             AddDependencyRequest
+		givenExitCode   int64
+		givenOutput     string
 		wantPulledImage string
 		wantCmd         []string
 		wantError       require.ErrorAssertionFunc
@@ -108,6 +171,96 @@ func TestTools_AddDependency(t *testing.T) {
 			},
 			wantError: require.NoError,
 		},
+		{
+			name: "success with node lockfile",
+			req: AddDependencyRequest{
+				Directory: myNodeFunctionDir,
+				Runtime:   "node22",
+				FromFile:  "package-lock.json",
+			},
+			wantPulledImage: "node:22-alpine",
+			wantCmd: []string{
+				"npm",
+				"ci",
+				"--prefix",
+				"/function",
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "success with python requirements file",
+			req: AddDependencyRequest{
+				Directory: myPythonFunctionDir,
+				Runtime:   "python3.13",
+				FromFile:  "requirements.txt",
+			},
+			wantPulledImage: constants.PublicRuntimesRegistry + "/python-dep:3.13",
+			wantCmd: []string{
+				"pip",
+				"install",
+				"-r",
+				"/function/requirements.txt",
+				"--target",
+				"/function/" + constants.PythonPackageFolder,
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "install failure reports the output",
+			req: AddDependencyRequest{
+				Directory: myPythonFunctionDir,
+				Runtime:   "python3.13",
+				Package:   "does-not-exist",
+			},
+			givenExitCode:   1,
+			givenOutput:     "ERROR: No matching distribution found for does-not-exist\n",
+			wantPulledImage: constants.PublicRuntimesRegistry + "/python-dep:3.13",
+			wantCmd: []string{
+				"pip",
+				"install",
+				"does-not-exist",
+				"--target",
+				"/function/" + constants.PythonPackageFolder,
+			},
+			wantError: func(tt require.TestingT, err error, _ ...any) {
+				require.ErrorIs(tt, err, ErrDependencyInstallFailed)
+				require.ErrorContains(tt, err, "No matching distribution found for does-not-exist")
+			},
+		},
+		{
+			name: "unsupported node dependency file",
+			req: AddDependencyRequest{
+				Directory: myNodeFunctionDir,
+				Runtime:   "node22",
+				FromFile:  "yarn.lock",
+			},
+			wantError: func(tt require.TestingT, err error, _ ...any) {
+				require.ErrorIs(tt, err, ErrUnsupportedDependencyFile)
+			},
+		},
+		{
+			name: "dependency file outside of the function directory",
+			req: AddDependencyRequest{
+				Directory: myPythonFunctionDir,
+				Runtime:   "python3.13",
+				FromFile:  "../my-node-function/package-lock.json",
+			},
+			wantError: func(tt require.TestingT, err error, _ ...any) {
+				require.ErrorIs(tt, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "both package and dependency file",
+			req: AddDependencyRequest{
+				Directory: myPythonFunctionDir,
+				Runtime:   "python3.13",
+				Package:   "requests",
+				FromFile:  "requirements.txt",
+			},
+			wantError: func(tt require.TestingT, err error, _ ...any) {
+				require.ErrorIs(tt, err, ErrInvalidValue)
+			},
+		},
 		{
 			name: "unsupported runtime",
 			req: AddDependencyRequest{
@@ -180,6 +333,11 @@ func TestTools_AddDependency(t *testing.T) {
 					ID: fixed.SomeDockerContainerID,
 				}, nil).Once()
 
+				mockDockerAPI.EXPECT().
+					ContainerAttach(mock.Anything, fixed.SomeDockerContainerID, mock.Anything).
+					Return(newHijackedResponse(t, tc.givenOutput), nil).
+					Once()
+
 				mockDockerAPI.EXPECT().
 					ContainerStart(mock.Anything, fixed.SomeDockerContainerID, mock.Anything).
 					Return(nil).
@@ -199,7 +357,7 @@ func TestTools_AddDependency(t *testing.T) {
 				// Simulate the container finishing successfully
 				go func() {
 					waitRespChan <- container.WaitResponse{
-						StatusCode: 0,
+						StatusCode: tc.givenExitCode,
 					}
 
 					close(waitRespChan)
@@ -0,0 +1,69 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+//nolint:gochecknoglobals
+var applyManifestTool = &mcp.Tool{
+	Name: "apply_manifest",
+	Description: `Create, update or delete the Scaleway Functions resources which differ from a manifest file.
+		Use "plan_manifest" first to review the changes.
+		Changes are applied in order, and the first failure stops the others.
+
+		` + manifestDescription,
+}
+
+type ApplyManifestRequest = PlanManifestRequest
+
+type ApplyManifestResponse struct {
+	Changes []ManifestChange `json:"changes"`
+	// Deployments holds the created and updated functions. Failed builds come with their logs.
+	Deployments []FunctionDeployment `json:"deployments,omitempty"`
+}
+
+func (t *Tools) ApplyManifest(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	in ApplyManifestRequest,
+) (*mcp.CallToolResult, ApplyManifestResponse, error) {
+	manifest, err := LoadManifest(in.path())
+	if err != nil {
+		return nil, ApplyManifestResponse{}, err
+	}
+
+	changes, err := t.planManifest(ctx, in.Scope, manifest)
+	if err != nil {
+		return nil, ApplyManifestResponse{}, err
+	}
+
+	resp := ApplyManifestResponse{
+		Changes: make([]ManifestChange, 0, len(changes)),
+	}
+
+	for i, change := range changes {
+		deployment, err := change.apply(ctx, req)
+		if err != nil {
+			return nil, ApplyManifestResponse{}, fmt.Errorf(
+				"applying change %d of %d (%s %s %q): %w",
+				i+1,
+				len(changes),
+				change.Action,
+				change.Resource,
+				change.Name,
+				err,
+			)
+		}
+
+		resp.Changes = append(resp.Changes, change.ManifestChange)
+
+		if deployment != nil {
+			resp.Deployments = append(resp.Deployments, *deployment)
+		}
+	}
+
+	return nil, resp, nil
+}
@@ -0,0 +1,148 @@
+package scaleway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_ApplyManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "my-function"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my-function", "handler.py"), []byte("print(1)"), 0o600))
+
+	// The privacy and HTTP option match the live ones, whatever their case.
+	manifest := `
+namespaces:
+  - name: ` + fixed.SomeNamespaceName + `
+    functions:
+      - name: ` + fixed.SomeFunctionName + `
+        directory: my-function
+        runtime: python313
+        handler: handler.new_handle
+        privacy: Public
+        http_option: Redirected
+        cron_triggers:
+          - {name: daily, schedule: "0 7 * * *"}
+`
+	manifestPath := filepath.Join(dir, DefaultManifestFile)
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifest), 0o600))
+
+	ownedTags := setCodeArchiveDigestTag(
+		[]string{constants.TagCreatedByScalewayMCP},
+		archiveDigest(t, filepath.Join(dir, "my-function")),
+	)
+
+	liveFunction := &function.Function{
+		ID:          fixed.SomeFunctionID,
+		Name:        fixed.SomeFunctionName,
+		NamespaceID: fixed.SomeNamespaceID,
+		Region:      scw.RegionNlAms,
+		Runtime:     function.FunctionRuntimePython313,
+		Handler:     "handler.handle",
+		Privacy:     function.FunctionPrivacyPublic,
+		HTTPOption:  function.FunctionHTTPOptionRedirected,
+		Tags:        ownedTags,
+	}
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+	mockFunctionsAPI.EXPECT().ListNamespaces(&function.ListNamespacesRequest{
+		Name: scw.StringPtr(fixed.SomeNamespaceName),
+	}, mock.Anything).Return(&function.ListNamespacesResponse{
+		Namespaces: []*function.Namespace{{
+			ID:     fixed.SomeNamespaceID,
+			Name:   fixed.SomeNamespaceName,
+			Region: scw.RegionNlAms,
+		}},
+	}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+		Region:      scw.RegionNlAms,
+		NamespaceID: fixed.SomeNamespaceID,
+	}, mock.Anything, mock.Anything).Return(&function.ListFunctionsResponse{
+		Functions: []*function.Function{
+			liveFunction,
+			{ID: "some-old-function-id", Name: "old-function", Region: scw.RegionNlAms, Tags: ownedTags},
+		},
+	}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().ListCrons(&function.ListCronsRequest{
+		Region:     scw.RegionNlAms,
+		FunctionID: fixed.SomeFunctionID,
+	}, mock.Anything, mock.Anything).Return(&function.ListCronsResponse{
+		Crons: []*function.Cron{
+			{ID: someCronID, Name: "daily", Schedule: "0 6 * * *"},
+			{ID: "some-obsolete-cron-id", Name: "obsolete", Schedule: "0 6 * * *"},
+		},
+	}, nil).Once()
+
+	// The changes target the planned resources by ID: functions with the same names in other
+	// namespaces are never looked up.
+	mockFunctionsAPI.EXPECT().UpdateFunction(&function.UpdateFunctionRequest{
+		Region:     scw.RegionNlAms,
+		FunctionID: fixed.SomeFunctionID,
+		Handler:    scw.StringPtr("handler.new_handle"),
+	}, mock.Anything).Return(liveFunction, nil).Once()
+
+	mockFunctionsAPI.EXPECT().GetFunction(&function.GetFunctionRequest{
+		Region:     scw.RegionNlAms,
+		FunctionID: fixed.SomeFunctionID,
+	}, mock.Anything).Return(&function.Function{
+		ID:          fixed.SomeFunctionID,
+		Name:        fixed.SomeFunctionName,
+		NamespaceID: fixed.SomeNamespaceID,
+		Region:      scw.RegionNlAms,
+		Status:      function.FunctionStatusReady,
+		DomainName:  "my-function-xyz.functions.nl-ams.scw.cloud",
+	}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().ListDomains(&function.ListDomainsRequest{
+		Region:     scw.RegionNlAms,
+		FunctionID: fixed.SomeFunctionID,
+	}, mock.Anything, mock.Anything).Return(&function.ListDomainsResponse{}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().UpdateCron(&function.UpdateCronRequest{
+		Region:   scw.RegionNlAms,
+		CronID:   someCronID,
+		Schedule: scw.StringPtr("0 7 * * *"),
+	}, mock.Anything).Return(&function.Cron{ID: someCronID, Name: "daily"}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().DeleteCron(&function.DeleteCronRequest{
+		Region: scw.RegionNlAms,
+		CronID: "some-obsolete-cron-id",
+	}, mock.Anything).Return(&function.Cron{ID: "some-obsolete-cron-id", Name: "obsolete"}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().DeleteFunction(&function.DeleteFunctionRequest{
+		Region:     scw.RegionNlAms,
+		FunctionID: "some-old-function-id",
+	}, mock.Anything).Return(&function.Function{ID: "some-old-function-id", Name: "old-function"}, nil).Once()
+
+	tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+	_, got, err := tools.ApplyManifest(t.Context(), nil, ApplyManifestRequest{
+		ManifestPath: manifestPath,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ManifestChange{
+		{Action: "update", Resource: "function", Name: "my-namespace/my-function", Fields: []string{"handler"}},
+		{Action: "update", Resource: "cron_trigger", Name: "my-namespace/my-function/daily", Fields: []string{"schedule"}},
+		{Action: "delete", Resource: "cron_trigger", Name: "my-namespace/my-function/obsolete"},
+		{Action: "delete", Resource: "function", Name: "my-namespace/old-function"},
+	}, got.Changes)
+
+	require.Len(t, got.Deployments, 1)
+	assert.Equal(t, []string{"my-function-xyz.functions.nl-ams.scw.cloud"}, got.Deployments[0].Function.Hostnames)
+}
@@ -4,28 +4,62 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"slices"
+	"log/slog"
+	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
 	cockpit "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 const (
-	tokenName = constants.ProjectName
+	tokenName = constants.ProjectName + "-logs"
+	// metricsTokenName is distinct from tokenName, as the tokens are stored by name and have different scopes.
+	metricsTokenName = constants.ProjectName + "-metrics-read"
+
+	// legacyTokenName is the name of the logs tokens created by older versions, which recreated them
+	// on every start. It differs from tokenName, so that they can be deleted without deleting the
+	// tokens of up-to-date instances.
+	legacyTokenName = constants.ProjectName
 
 	// queryTemplateServerless is the template used to query logs from Loki for Serverless Functions & Containers.
-	// Because Serverless logs are sent as JSON, we only get the message field.
-	queryTemplateServerless = `{resource_name="%s", resource_type="%s"} |~ "^{.*}$" | json | line_format "{{.message}}"`
+	// Because Serverless logs are sent as JSON, we only get the message field. Filters are appended to it.
+	queryTemplateServerless = `{resource_name="%s", resource_type="%s"} |~ "^{.*}$" | json`
+	lineFormatServerless    = ` | line_format "{{.message}}"`
+
+	// queryTemplateServerlessBuild is the template used to query build logs from Loki.
+	// Unlike runtime logs, build logs are sent as plain text lines (e.g. pip or npm output).
+	queryTemplateServerlessBuild = `{resource_name="%s", resource_type="%s"}`
+
+	resourceTypeFunction      = "serverless_function"
+	resourceTypeFunctionBuild = "serverless_function_build"
+
+	// tokenGracePeriod is how long a new token may be rejected while it propagates. During that time,
+	// it's tried again instead of being rotated.
+	tokenGracePeriod   = 30 * time.Second
+	tokenRetryInterval = time.Second
+
+	// maxBuildLogs is the maximum number of lines of a build. Builds are short, so it's
+	// only reached by very verbose builds.
+	maxBuildLogs = 5000
 )
 
 var (
 	ErrNoScalewayLogsDataSource = errors.New(
 		"no Scaleway logs data source found; please wait a few minutes and try again",
 	)
+	ErrNoScalewayMetricsDataSource = errors.New(
+		"no Scaleway metrics data source found; please wait a few minutes and try again",
+	)
 	ErrTokenHasNoSecretKey = errors.New("token has no secret key")
+	ErrUnknownMetric       = errors.New("unknown metric")
+	// ErrUnauthorized is returned by the data source clients when the token is rejected,
+	// for instance because it was deleted.
+	ErrUnauthorized = errors.New("cockpit token was rejected")
 )
 
 type Log struct {
@@ -33,13 +67,45 @@ type Log struct {
 	Message   string    `json:"message"`
 }
 
+// LogQuery selects the logs of a function.
+type LogQuery struct {
+	Start     time.Time
+	End       time.Time
+	Limit     int
+	Direction Direction
+
+	// Contains only keeps the messages containing the string.
+	Contains string
+	// Regexp only keeps the messages matching the regular expression (RE2 syntax).
+	Regexp string
+	// Level only keeps the logs of the given level (e.g. "error"), when the runtime sets it.
+	Level string
+}
+
+// logQL returns the LogQL query of the logs of the resource.
+func (q LogQuery) logQL(resourceName string) string {
+	query := fmt.Sprintf(queryTemplateServerless, resourceName, resourceTypeFunction)
+
+	// The level is a field of the JSON log, so it's filtered before the message is extracted.
+	if q.Level != "" {
+		query += ` | level=~` + strconv.Quote("(?i)"+regexp.QuoteMeta(q.Level))
+	}
+
+	query += lineFormatServerless
+
+	if q.Contains != "" {
+		query += ` |= ` + strconv.Quote(q.Contains)
+	}
+
+	if q.Regexp != "" {
+		query += ` |~ ` + strconv.Quote(q.Regexp)
+	}
+
+	return query
+}
+
 type Client interface {
-	ListFunctionLogs(
-		ctx context.Context,
-		resourceName string,
-		start time.Time,
-		end time.Time,
-	) ([]Log, error)
+	ListFunctionLogs(ctx context.Context, resourceName string, query LogQuery) ([]Log, error)
 	// note(cyclimse): makes me think we should have a buildID in Scaleway Functions build logs
 	//                 to link logs to a specific build.
 	ListFunctionBuildLogs(
@@ -48,133 +114,387 @@ type Client interface {
 		start time.Time,
 		end time.Time,
 	) ([]Log, error)
+	ListFunctionMetrics(ctx context.Context, resourceName string, query MetricQuery) ([]MetricSeries, error)
+}
+
+// dataSource holds the URL and the token of a Scaleway data source, which are fetched lazily.
+type dataSource struct {
+	dataSourceType cockpit.DataSourceType
+	tokenName      string
+	// legacyTokenName is the name of the tokens created by older versions, if any.
+	legacyTokenName string
+	tokenScope      cockpit.TokenScope
+
+	mu    sync.Mutex
+	url   string
+	token *Token
 }
 
 type client struct {
 	cockpitAPI *cockpit.RegionalAPI
 	projectID  string
+	region     scw.Region
+	// tokens is optional: without it, new tokens are created every time the server starts.
+	tokens TokenStore
 
-	initLokiClientOnce sync.Once
-	lokiClient         LokiClient
+	tokenGracePeriod   time.Duration
+	tokenRetryInterval time.Duration
+
+	logs    *dataSource
+	metrics *dataSource
 }
 
-func NewClient(scwClient *scw.Client, projectID string) Client {
+// NewClient returns the client of the Cockpit of a project in a region. Without a region,
+// the default region of the Scaleway client is used.
+func NewClient(scwClient *scw.Client, projectID string, region scw.Region, tokens TokenStore) Client {
+	if region == "" {
+		region, _ = scwClient.GetDefaultRegion()
+	}
+
 	return &client{
 		cockpitAPI: cockpit.NewRegionalAPI(scwClient),
 		projectID:  projectID,
+		region:     region,
+		tokens:     tokens,
+
+		tokenGracePeriod:   tokenGracePeriod,
+		tokenRetryInterval: tokenRetryInterval,
+
+		logs: &dataSource{
+			dataSourceType:  cockpit.DataSourceTypeLogs,
+			tokenName:       tokenName,
+			legacyTokenName: legacyTokenName,
+			tokenScope:      cockpit.TokenScopeReadOnlyLogs,
+		},
+		metrics: &dataSource{
+			dataSourceType: cockpit.DataSourceTypeMetrics,
+			tokenName:      metricsTokenName,
+			tokenScope:     cockpit.TokenScopeReadOnlyMetrics,
+		},
 	}
 }
 
 // ListFunctionLogs implements Client.
 func (c *client) ListFunctionLogs(
+	ctx context.Context,
+	resourceName string,
+	query LogQuery,
+) ([]Log, error) {
+	logs, err := withDataSource(ctx, c, c.logs, func(url, secretKey string) ([]Log, error) {
+		return NewLokiClient(url, secretKey).Query(ctx, QueryRangeRequest{
+			Query:     query.logQL(resourceName),
+			Start:     query.Start,
+			End:       query.End,
+			Limit:     query.Limit,
+			Direction: query.Direction,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// ListFunctionBuildLogs implements Client.
+func (c *client) ListFunctionBuildLogs(
 	ctx context.Context,
 	resourceName string,
 	start time.Time,
 	end time.Time,
 ) ([]Log, error) {
-	lokiClient, err := c.getLokiClient(ctx)
+	logs, err := withDataSource(ctx, c, c.logs, func(url, secretKey string) ([]Log, error) {
+		return NewLokiClient(url, secretKey).Query(ctx, QueryRangeRequest{
+			Query: fmt.Sprintf(queryTemplateServerlessBuild, resourceName, resourceTypeFunctionBuild),
+			Start: start,
+			End:   end,
+			Limit: maxBuildLogs,
+			// Build output only makes sense when read from top to bottom.
+			Direction: DirectionForward,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("getting Loki client: %w", err)
+		return nil, fmt.Errorf("querying build logs: %w", err)
 	}
 
-	logs, err := lokiClient.Query(
-		ctx,
-		fmt.Sprintf(queryTemplateServerless, resourceName, "serverless_function"),
-		start,
-		end,
-	)
+	return logs, nil
+}
+
+// ListFunctionMetrics implements Client.
+func (c *client) ListFunctionMetrics(
+	ctx context.Context,
+	resourceName string,
+	query MetricQuery,
+) ([]MetricSeries, error) {
+	queries, unit, err := query.promQL(resourceName)
 	if err != nil {
-		return nil, fmt.Errorf("querying logs: %w", err)
+		return nil, err
 	}
 
-	return logs, nil
+	metrics := make([]MetricSeries, 0, len(queries))
+
+	for _, nq := range queries {
+		series, err := withDataSource(ctx, c, c.metrics, func(url, secretKey string) ([]Series, error) {
+			return NewPrometheusClient(url, secretKey).QueryRange(ctx, MetricsQueryRangeRequest{
+				Query: nq.query,
+				Start: query.Start,
+				End:   query.End,
+				Step:  query.Step,
+			})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("querying %s metric %q: %w", query.Metric, nq.name, err)
+		}
+
+		// The queries are aggregated, so there is at most one series, and none without data.
+		points := []Point{}
+		for _, s := range series {
+			points = append(points, s.Points...)
+		}
+
+		metrics = append(metrics, MetricSeries{
+			Metric: query.Metric,
+			Name:   nq.name,
+			Unit:   unit,
+			Points: points,
+		})
+	}
+
+	return metrics, nil
 }
 
-// ListFunctionBuildLogs implements Client.
-func (*client) ListFunctionBuildLogs(
-	_ context.Context,
-	_ string,
-	_ time.Time,
-	_ time.Time,
-) ([]Log, error) {
-	panic("unimplemented")
+// withDataSource calls fn with the URL and the secret key of the data source. When the token
+// is rejected past its grace period, it is rotated and fn is called once more.
+func withDataSource[T any](
+	ctx context.Context,
+	c *client,
+	ds *dataSource,
+	fn func(url, secretKey string) (T, error),
+) (T, error) {
+	var zero T
+
+	url, token, err := c.getDataSource(ctx, ds)
+	if err != nil {
+		return zero, err
+	}
+
+	res, err := callWithToken(ctx, c, url, token, fn)
+	if !errors.Is(err, ErrUnauthorized) {
+		return res, err
+	}
+
+	slogctx.FromContext(ctx).InfoContext(ctx, "cockpit token was rejected, rotating it", "token_name", ds.tokenName)
+
+	token, err = c.rotateToken(ctx, ds, token)
+	if err != nil {
+		return zero, fmt.Errorf("rotating token: %w", err)
+	}
+
+	return callWithToken(ctx, c, url, token, fn)
 }
 
-//nolint:nonamedreturns // actually like it this way.
-func (c *client) getLokiClient(ctx context.Context) (lokiClient LokiClient, err error) {
-	c.initLokiClientOnce.Do(func() {
-		var (
-			dataSource string
-			token      string
-		)
+// callWithToken calls fn with the secret key of the token. Until the grace period of the token
+// is over, it's tried again with backoff when rejected.
+func callWithToken[T any](
+	ctx context.Context,
+	c *client,
+	url string,
+	token *Token,
+	fn func(url, secretKey string) (T, error),
+) (T, error) {
+	res, err := fn(url, token.SecretKey)
+
+	for wait := c.tokenRetryInterval; errors.Is(err, ErrUnauthorized); wait *= 2 {
+		if time.Since(token.CreatedAt) >= c.tokenGracePeriod {
+			break
+		}
+
+		slogctx.FromContext(ctx).DebugContext(ctx, "new cockpit token was rejected, retrying", "token_id", token.ID)
 
-		dataSource, err = c.getScalewayLogsDataSourceURL(ctx)
+		select {
+		case <-ctx.Done():
+			var zero T
+
+			return zero, fmt.Errorf("context done while waiting for token: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+
+		res, err = fn(url, token.SecretKey)
+	}
+
+	return res, err
+}
+
+func (c *client) getDataSource(ctx context.Context, ds *dataSource) (string, *Token, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.url == "" {
+		url, err := c.getScalewayDataSourceURL(ctx, ds.dataSourceType)
 		if err != nil {
-			err = fmt.Errorf(
-				"getting Scaleway logs data source for project %q: %w",
+			return "", nil, fmt.Errorf(
+				"getting Scaleway %s data source for project %q: %w",
+				ds.dataSourceType,
 				c.projectID,
 				err,
 			)
+		}
 
-			return
+		ds.url = url
+	}
+
+	if ds.token == nil {
+		token, err := c.getToken(ctx, ds, nil)
+		if err != nil {
+			return "", nil, err
 		}
 
-		token, err = c.createToken(ctx)
+		ds.token = token
+	}
+
+	return ds.url, ds.token, nil
+}
+
+// rotateToken replaces the rejected token, unless it was already replaced by a concurrent call.
+func (c *client) rotateToken(ctx context.Context, ds *dataSource, rejected *Token) (*Token, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.token != nil && ds.token.SecretKey != rejected.SecretKey {
+		return ds.token, nil
+	}
+
+	token, err := c.getToken(ctx, ds, rejected)
+	if err != nil {
+		return nil, err
+	}
+
+	ds.token = token
+
+	return token, nil
+}
+
+// getToken returns the stored token of the data source, unless it's the rejected one.
+// Otherwise, a new token is created and stored for the next calls and the other instances.
+//
+// Tokens with the current names are never deleted by name, as they may be in use by other
+// instances of the server. Only the rejected token is deleted, since it can't be used anyway.
+func (c *client) getToken(ctx context.Context, ds *dataSource, rejected *Token) (*Token, error) {
+	logger := slogctx.FromContext(ctx)
+	key := TokenKey{ProjectID: c.projectID, Region: c.region, Name: ds.tokenName}
+
+	var stored *Token
+
+	if c.tokens != nil {
+		var err error
+
+		stored, err = c.tokens.Load(key)
 		if err != nil {
-			err = fmt.Errorf("creating token: %w", err)
+			logger.WarnContext(ctx, "failed to load stored cockpit token", "error", err)
+		}
 
-			return
+		// Another instance may have rotated the token already.
+		if stored != nil && (rejected == nil || stored.SecretKey != rejected.SecretKey) {
+			return stored, nil
 		}
 
-		c.lokiClient = NewLokiClient(dataSource, token)
-	})
+		// Without a stored token, this is the first start since the tokens are stored.
+		if err == nil && stored == nil && ds.legacyTokenName != "" {
+			c.deleteLegacyTokens(ctx, ds)
+		}
+	}
+
+	if rejected != nil && rejected.ID != "" {
+		// Most likely, the token was rejected because it was already deleted.
+		c.deleteToken(ctx, rejected.ID, slog.LevelDebug)
+	}
+
+	token, err := c.createToken(ctx, ds.tokenName, ds.tokenScope)
+	if err != nil {
+		return nil, fmt.Errorf("creating token: %w", err)
+	}
+
+	if c.tokens == nil {
+		return token, nil
+	}
+
+	current, err := c.tokens.CompareAndSwap(key, stored, token)
+	if err != nil {
+		// The token can still be used, it will only be recreated on the next start.
+		logger.WarnContext(ctx, "failed to store cockpit token", "error", err)
+
+		return token, nil
+	}
 
-	return c.lokiClient, err
+	if !sameToken(current, token) {
+		// Another instance stored a new token in the meantime: it is used instead, so that
+		// the instances don't keep replacing each other's tokens.
+		c.deleteToken(ctx, token.ID, slog.LevelWarn)
+
+		return current, nil
+	}
+
+	return token, nil
 }
 
-func (c *client) createToken(ctx context.Context) (string, error) {
+// deleteLegacyTokens deletes the tokens created by older versions, which are not used anymore.
+func (c *client) deleteLegacyTokens(ctx context.Context, ds *dataSource) {
 	resp, err := c.cockpitAPI.ListTokens(&cockpit.RegionalAPIListTokensRequest{
-		TokenScopes: []cockpit.TokenScope{cockpit.TokenScopeReadOnlyLogs},
+		Region:      c.region,
 		ProjectID:   c.projectID,
+		TokenScopes: []cockpit.TokenScope{ds.tokenScope},
 	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
-		return "", fmt.Errorf("listing tokens: %w", err)
-	}
+		slogctx.FromContext(ctx).WarnContext(ctx, "failed to list legacy cockpit tokens", "error", err)
 
-	i := slices.IndexFunc(resp.Tokens, func(t *cockpit.Token) bool {
-		return t.Name == tokenName
-	})
+		return
+	}
 
-	if i != -1 {
-		// Unfortunately, the SecretKey is only shown once. So we're going to have to
-		// delete and recreate it.
-		err := c.cockpitAPI.DeleteToken(&cockpit.RegionalAPIDeleteTokenRequest{
-			TokenID: resp.Tokens[i].ID,
-		}, scw.WithContext(ctx))
-		if err != nil {
-			return "", fmt.Errorf("deleting existing token: %w", err)
+	for _, token := range resp.Tokens {
+		if token.Name == ds.legacyTokenName {
+			c.deleteToken(ctx, token.ID, slog.LevelWarn)
 		}
 	}
+}
 
+// deleteToken deletes a token, logging failures at the given level.
+func (c *client) deleteToken(ctx context.Context, tokenID string, failureLevel slog.Level) {
+	err := c.cockpitAPI.DeleteToken(&cockpit.RegionalAPIDeleteTokenRequest{
+		Region:  c.region,
+		TokenID: tokenID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		slogctx.FromContext(ctx).Log(ctx, failureLevel, "failed to delete cockpit token",
+			"token_id", tokenID,
+			"error", err,
+		)
+	}
+}
+
+func (c *client) createToken(ctx context.Context, name string, scope cockpit.TokenScope) (*Token, error) {
 	token, err := c.cockpitAPI.CreateToken(&cockpit.RegionalAPICreateTokenRequest{
-		Name:        tokenName,
-		TokenScopes: []cockpit.TokenScope{cockpit.TokenScopeReadOnlyLogs},
+		Region:      c.region,
+		Name:        name,
+		TokenScopes: []cockpit.TokenScope{scope},
+		ProjectID:   c.projectID,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return "", fmt.Errorf("creating token: %w", err)
+		return nil, fmt.Errorf("creating token: %w", err)
 	}
 
+	// Unfortunately, the SecretKey is only shown once, which is why the token is stored.
 	if token.SecretKey == nil {
-		return "", ErrTokenHasNoSecretKey
+		return nil, ErrTokenHasNoSecretKey
 	}
 
-	return *token.SecretKey, nil
+	return &Token{ID: token.ID, SecretKey: *token.SecretKey, CreatedAt: time.Now()}, nil
 }
 
-func (c *client) getScalewayLogsDataSourceURL(ctx context.Context) (string, error) {
+func (c *client) getScalewayDataSourceURL(ctx context.Context, dataSourceType cockpit.DataSourceType) (string, error) {
 	resp, err := c.cockpitAPI.ListDataSources(&cockpit.RegionalAPIListDataSourcesRequest{
+		Region:    c.region,
 		Origin:    cockpit.DataSourceOriginScaleway,
-		Types:     []cockpit.DataSourceType{cockpit.DataSourceTypeLogs},
+		Types:     []cockpit.DataSourceType{dataSourceType},
 		ProjectID: c.projectID,
 		// There should be at most one such data source.
 		Page:     scw.Int32Ptr(1),
@@ -185,6 +505,10 @@ func (c *client) getScalewayLogsDataSourceURL(ctx context.Context) (string, erro
 	}
 
 	if len(resp.DataSources) == 0 {
+		if dataSourceType == cockpit.DataSourceTypeMetrics {
+			return "", ErrNoScalewayMetricsDataSource
+		}
+
 		return "", ErrNoScalewayLogsDataSource
 	}
 
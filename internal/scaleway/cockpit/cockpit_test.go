@@ -0,0 +1,247 @@
+package cockpit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const someProjectID = "11111111-1111-1111-1111-111111111111"
+
+// testCockpit serves both the Cockpit API and the Loki data source. Only the tokens
+// with a valid secret key are accepted by Loki.
+type testCockpit struct {
+	validSecretKeys []string
+	// propagating is how many times each valid secret key is still rejected, as for a new token.
+	propagating map[string]int
+	// onCreateToken is called before a token is created, e.g. to act as another instance.
+	onCreateToken func()
+
+	mu      sync.Mutex
+	created int
+	deleted []string
+}
+
+func newTestCockpit(t *testing.T, tc *testCockpit) *scw.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	var server *httptest.Server
+
+	mux.HandleFunc("GET /cockpit/v1/regions/fr-par/data-sources", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count": 1, "data_sources": [{"url": "` + server.URL + `"}]}`))
+	})
+
+	mux.HandleFunc("GET /cockpit/v1/regions/fr-par/tokens", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count": 2, "tokens": [
+			{"id": "legacy-token-id", "name": "` + legacyTokenName + `"},
+			{"id": "other-instance-token-id", "name": "` + tokenName + `"}
+		]}`))
+	})
+
+	mux.HandleFunc("POST /cockpit/v1/regions/fr-par/tokens", func(w http.ResponseWriter, _ *http.Request) {
+		if tc.onCreateToken != nil {
+			tc.onCreateToken()
+		}
+
+		tc.mu.Lock()
+		tc.created++
+		tc.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "new-token-id", "secret_key": "valid-secret-key"}`))
+	})
+
+	mux.HandleFunc("DELETE /cockpit/v1/regions/fr-par/tokens/{id}", func(w http.ResponseWriter, r *http.Request) {
+		tc.mu.Lock()
+		tc.deleted = append(tc.deleted, r.PathValue("id"))
+		tc.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET /loki/api/v1/query_range", func(w http.ResponseWriter, r *http.Request) {
+		secretKey := r.Header.Get("X-Token")
+
+		tc.mu.Lock()
+		propagating := tc.propagating[secretKey] > 0
+		if propagating {
+			tc.propagating[secretKey]--
+		}
+		tc.mu.Unlock()
+
+		if propagating || !slices.Contains(tc.validSecretKeys, secretKey) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"status": "success",
+			"data": {"resultType": "streams", "result": [{"stream": {}, "values": [["1000", "hello"]]}]}
+		}`))
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	scwClient, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", someProjectID),
+		scw.WithHTTPClient(server.Client()),
+	)
+	require.NoError(t, err)
+
+	return scwClient
+}
+
+func TestClient_ListFunctionLogs_Token(t *testing.T) {
+	t.Parallel()
+
+	key := TokenKey{ProjectID: someProjectID, Region: scw.RegionFrPar, Name: tokenName}
+
+	newToken := &Token{ID: "new-token-id", SecretKey: "valid-secret-key"}
+	otherInstanceToken := &Token{ID: "other-instance-token-id", SecretKey: "other-secret-key"}
+	youngToken := &Token{ID: "young-token-id", SecretKey: "young-secret-key", CreatedAt: time.Now()}
+
+	tt := []struct {
+		name        string
+		storedToken *Token
+		// propagating is how many times the stored token is rejected before being accepted.
+		propagating int
+		// otherInstanceRotates stores a token while this instance creates its own.
+		otherInstanceRotates bool
+		wantCreated          int
+		wantDeleted          []string
+		wantStored           *Token
+	}{
+		{
+			name:        "no stored token",
+			wantCreated: 1,
+			// Only the token of the older versions is deleted.
+			wantDeleted: []string{"legacy-token-id"},
+			wantStored:  newToken,
+		},
+		{
+			name:        "stored token is reused",
+			storedToken: newToken,
+			wantStored:  newToken,
+		},
+		{
+			name:        "rejected token is rotated",
+			storedToken: &Token{ID: "old-token-id", SecretKey: "old-secret-key"},
+			wantCreated: 1,
+			wantDeleted: []string{"old-token-id"},
+			wantStored:  newToken,
+		},
+		{
+			name:        "new token is retried while it propagates",
+			storedToken: youngToken,
+			propagating: 2,
+			wantStored:  youngToken,
+		},
+		{
+			name:                 "token rotated by another instance meanwhile",
+			storedToken:          &Token{ID: "old-token-id", SecretKey: "old-secret-key"},
+			otherInstanceRotates: true,
+			wantCreated:          1,
+			// The token of the other instance is kept, and ours is deleted.
+			wantDeleted: []string{"old-token-id", "new-token-id"},
+			wantStored:  otherInstanceToken,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokens := NewFileTokenStore(t.TempDir())
+			if tc.storedToken != nil {
+				_, err := tokens.CompareAndSwap(key, nil, tc.storedToken)
+				require.NoError(t, err)
+			}
+
+			cockpit := &testCockpit{
+				validSecretKeys: []string{"valid-secret-key", "other-secret-key", "young-secret-key"},
+				propagating:     map[string]int{"young-secret-key": tc.propagating},
+			}
+			if tc.otherInstanceRotates {
+				cockpit.onCreateToken = func() {
+					_, err := tokens.CompareAndSwap(key, tc.storedToken, otherInstanceToken)
+					assert.NoError(t, err)
+				}
+			}
+
+			scwClient := newTestCockpit(t, cockpit)
+
+			c, ok := NewClient(scwClient, someProjectID, scw.RegionFrPar, tokens).(*client)
+			require.True(t, ok)
+
+			c.tokenRetryInterval = time.Millisecond
+
+			logs, err := c.ListFunctionLogs(
+				t.Context(),
+				"my-function",
+				LogQuery{Start: time.Unix(0, 0), End: time.Unix(0, 2000), Limit: 10, Direction: DirectionBackward},
+			)
+			require.NoError(t, err)
+			assert.Equal(t, []Log{{Timestamp: time.Unix(0, 1000), Message: "hello"}}, logs)
+
+			assert.Equal(t, tc.wantCreated, cockpit.created)
+			assert.Equal(t, tc.wantDeleted, cockpit.deleted)
+
+			// The valid token is kept for the next start.
+			stored, err := tokens.Load(key)
+			require.NoError(t, err)
+			assert.True(t, sameToken(tc.wantStored, stored), "stored token: %+v", stored)
+		})
+	}
+}
+
+func TestFileTokenStore(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tokens := NewFileTokenStore(dir)
+	key := TokenKey{ProjectID: someProjectID, Region: scw.RegionFrPar, Name: tokenName}
+
+	token, err := tokens.Load(key)
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	someToken := &Token{ID: "some-token-id", SecretKey: "some-secret-key"}
+
+	token, err = tokens.CompareAndSwap(key, nil, someToken)
+	require.NoError(t, err)
+	assert.Equal(t, someToken, token)
+
+	token, err = tokens.Load(key)
+	require.NoError(t, err)
+	assert.Equal(t, someToken, token)
+
+	// The token was stored since: it is not replaced.
+	token, err = tokens.CompareAndSwap(key, nil, &Token{ID: "other-token-id", SecretKey: "other-secret-key"})
+	require.NoError(t, err)
+	assert.Equal(t, someToken, token)
+
+	// Tokens of other regions are kept apart.
+	token, err = tokens.Load(TokenKey{ProjectID: someProjectID, Region: scw.RegionNlAms, Name: tokenName})
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	info, err := os.Stat(dir + "/" + someProjectID + "_fr-par_" + tokenName + ".json")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
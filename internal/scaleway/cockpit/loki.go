@@ -1,19 +1,41 @@
 package cockpit
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
 var ErrQueryNotSuccessful = errors.New("loki query was not successful")
 
+// Direction is the order in which Loki returns the logs. It also decides which logs are
+// returned when there are more than the limit: the newest ones when going backward.
+type Direction string
+
+const (
+	DirectionBackward Direction = "backward"
+	DirectionForward  Direction = "forward"
+)
+
+type QueryRangeRequest struct {
+	Query     string
+	Start     time.Time
+	End       time.Time
+	Limit     int
+	Direction Direction
+}
+
 type LokiClient interface {
-	Query(ctx context.Context, query string, start time.Time, end time.Time) ([]Log, error)
+	// Query returns the logs of all the matching streams, merged in the order of the direction.
+	Query(ctx context.Context, req QueryRangeRequest) ([]Log, error)
 }
 
 type lokiClient struct {
@@ -34,12 +56,7 @@ func NewLokiClient(url string, secretKey string) LokiClient {
 }
 
 //nolint:funlen // necessary length.
-func (c *lokiClient) Query(
-	ctx context.Context,
-	query string,
-	start time.Time,
-	end time.Time,
-) ([]Log, error) {
+func (c *lokiClient) Query(ctx context.Context, in QueryRangeRequest) ([]Log, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
@@ -51,9 +68,13 @@ func (c *lokiClient) Query(
 	}
 
 	q := req.URL.Query()
-	q.Add("query", query)
-	q.Add("start", strconv.FormatInt(start.UnixNano(), 10))
-	q.Add("end", strconv.FormatInt(end.UnixNano(), 10))
+	q.Add("query", in.Query)
+	q.Add("start", strconv.FormatInt(in.Start.UnixNano(), 10))
+	q.Add("end", strconv.FormatInt(in.End.UnixNano(), 10))
+	q.Add("direction", string(in.Direction))
+
+	// Without a limit, Loki silently truncates the results to its default limit.
+	q.Add("limit", strconv.Itoa(in.Limit))
 
 	req.URL.RawQuery = q.Encode()
 
@@ -66,6 +87,10 @@ func (c *lokiClient) Query(
 		_ = resp.Body.Close()
 	}()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf(
 			"%w: unexpected status code (%d) from Loki",
@@ -87,18 +112,53 @@ func (c *lokiClient) Query(
 		)
 	}
 
-	var logs []Log
+	return mergeStreams(queryResp.Data.Result, in.Direction), nil
+}
+
+// mergeStreams merges the logs of the streams in the order of the direction. Each stream is sorted,
+// but the order of the streams and of the logs sharing a timestamp varies from one query to another:
+// the ties are broken by the labels of the streams, then by the messages, so that pages don't
+// overlap nor skip logs.
+func mergeStreams(streams Streams, direction Direction) []Log {
+	type labeledLog struct {
+		Log
+
+		labels string
+	}
+
+	var labeled []labeledLog
+
+	for _, stream := range streams {
+		labels := stream.Stream.String()
 
-	for _, stream := range queryResp.Data.Result {
 		for _, entry := range stream.Entries {
-			logs = append(logs, Log{
-				Timestamp: entry.Timestamp,
-				Message:   entry.Line,
+			labeled = append(labeled, labeledLog{
+				Log:    Log{Timestamp: entry.Timestamp, Message: entry.Line},
+				labels: labels,
 			})
 		}
 	}
 
-	return logs, nil
+	slices.SortFunc(labeled, func(a, b labeledLog) int {
+		c := cmp.Or(
+			a.Timestamp.Compare(b.Timestamp),
+			strings.Compare(a.labels, b.labels),
+			strings.Compare(a.Message, b.Message),
+		)
+
+		if direction == DirectionBackward {
+			return -c
+		}
+
+		return c
+	})
+
+	logs := make([]Log, 0, len(labeled))
+	for _, l := range labeled {
+		logs = append(logs, l.Log)
+	}
+
+	return logs
 }
 
 type QueryResponse struct {
@@ -116,14 +176,22 @@ type QueryResponseData struct {
 type Streams []Stream
 
 type Stream struct {
-	Stream  StreamMetadata `json:"stream"`
-	Entries []Entry        `json:"values"`
+	Stream  StreamLabels `json:"stream"`
+	Entries []Entry      `json:"values"`
 }
 
-type StreamMetadata struct {
-	ResourceID   string `json:"resource_id"`
-	ResourceName string `json:"resource_name"`
-	ResourceType string `json:"resource_type"`
+// StreamLabels are the labels of a stream, such as resource_name, along with the fields
+// extracted by the query.
+type StreamLabels map[string]string
+
+// String returns the labels in the LogQL selector syntax, sorted by name.
+func (l StreamLabels) String() string {
+	pairs := make([]string, 0, len(l))
+	for _, name := range slices.Sorted(maps.Keys(l)) {
+		pairs = append(pairs, name+"="+strconv.Quote(l[name]))
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
 }
 
 type roundTripper struct {
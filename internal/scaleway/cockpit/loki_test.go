@@ -0,0 +1,95 @@
+package cockpit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLokiClient_Query(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "some-secret-key", r.Header.Get("X-Token"))
+		assert.Equal(t, "/loki/api/v1/query_range", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		assert.Equal(t, "backward", r.URL.Query().Get("direction"))
+
+		// Two streams, e.g. stdout and stderr, each sorted by Loki.
+		_, _ = w.Write([]byte(`{
+			"status": "success",
+			"data": {
+				"resultType": "streams",
+				"result": [
+					{"stream": {"resource_name": "my-function"}, "values": [["3000", "c"], ["1000", "a"]]},
+					{"stream": {"resource_name": "my-function"}, "values": [["4000", "d"], ["2000", "b"]]}
+				]
+			}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	logs, err := NewLokiClient(server.URL, "some-secret-key").Query(t.Context(), QueryRangeRequest{
+		Query:     `{resource_name="my-function"}`,
+		Start:     time.Unix(0, 0),
+		End:       time.Unix(0, 5000),
+		Limit:     50,
+		Direction: DirectionBackward,
+	})
+	require.NoError(t, err)
+
+	messages := make([]string, 0, len(logs))
+	for _, log := range logs {
+		messages = append(messages, log.Message)
+	}
+
+	assert.Equal(t, []string{"d", "c", "b", "a"}, messages)
+}
+
+func TestMergeStreams(t *testing.T) {
+	t.Parallel()
+
+	stdout := Stream{
+		Stream:  StreamLabels{"resource_name": "my-function", "stream": "stdout"},
+		Entries: []Entry{{Timestamp: time.Unix(0, 2000), Line: "b"}, {Timestamp: time.Unix(0, 1000), Line: "a"}},
+	}
+	stderr := Stream{
+		Stream:  StreamLabels{"resource_name": "my-function", "stream": "stderr"},
+		Entries: []Entry{{Timestamp: time.Unix(0, 1000), Line: "z"}},
+	}
+
+	// The logs sharing a timestamp are ordered the same way, whatever the order of the streams.
+	for _, streams := range []Streams{{stdout, stderr}, {stderr, stdout}} {
+		assert.Equal(t, []Log{
+			{Timestamp: time.Unix(0, 1000), Message: "z"},
+			{Timestamp: time.Unix(0, 1000), Message: "a"},
+			{Timestamp: time.Unix(0, 2000), Message: "b"},
+		}, mergeStreams(streams, DirectionForward))
+
+		assert.Equal(t, []Log{
+			{Timestamp: time.Unix(0, 2000), Message: "b"},
+			{Timestamp: time.Unix(0, 1000), Message: "a"},
+			{Timestamp: time.Unix(0, 1000), Message: "z"},
+		}, mergeStreams(streams, DirectionBackward))
+	}
+}
+
+func TestLogQuery_logQL(t *testing.T) {
+	t.Parallel()
+
+	query := LogQuery{
+		Contains: `say "hi"`,
+		Regexp:   `^GET /`,
+		Level:    "error",
+	}
+
+	assert.Equal(t,
+		`{resource_name="my-function", resource_type="serverless_function"} |~ "^{.*}$" | json`+
+			` | level=~"(?i)error" | line_format "{{.message}}" |= "say \"hi\"" |~ "^GET /"`,
+		query.logQL("my-function"),
+	)
+}
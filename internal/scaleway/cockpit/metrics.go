@@ -0,0 +1,132 @@
+package cockpit
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Metric is a preset of the metrics of a function, made of one or more PromQL queries.
+type Metric string
+
+const (
+	MetricInvocations Metric = "invocations"
+	MetricErrors      Metric = "errors"
+	MetricDuration    Metric = "duration"
+	MetricColdStarts  Metric = "cold_starts"
+	MetricMemory      Metric = "memory"
+	MetricInstances   Metric = "instances"
+)
+
+// The queries are formatted with the label selector of the function and the range of the step.
+// The metrics are the ones of the Serverless Functions dashboard of Cockpit.
+// Reference: https://www.scaleway.com/en/docs/serverless-functions/how-to/monitor-function/
+// A metric which is not collected has no series, which the caller reports as having no data.
+//
+//nolint:gochecknoglobals
+var metricPresets = map[Metric]metricPreset{
+	MetricInvocations: {
+		unit: "requests",
+		queries: []namedQuery{
+			{name: "invocations", query: `sum(increase(serverless_function_requests_total{%[1]s}[%[2]s]))`},
+		},
+	},
+	MetricErrors: {
+		unit: "requests",
+		queries: []namedQuery{
+			{name: "4xx", query: `sum(increase(serverless_function_requests_total{%[1]s, status_code=~"4.."}[%[2]s]))`},
+			{name: "5xx", query: `sum(increase(serverless_function_requests_total{%[1]s, status_code=~"5.."}[%[2]s]))`},
+		},
+	},
+	MetricDuration: {
+		unit: "seconds",
+		queries: []namedQuery{
+			{name: "p50", query: durationQuantileQuery(0.5)},
+			{name: "p95", query: durationQuantileQuery(0.95)},
+			{name: "p99", query: durationQuantileQuery(0.99)},
+		},
+	},
+	MetricColdStarts: {
+		unit: "cold starts",
+		queries: []namedQuery{
+			{name: "cold_starts", query: `sum(increase(serverless_function_cold_starts_total{%[1]s}[%[2]s]))`},
+		},
+	},
+	MetricMemory: {
+		unit: "bytes",
+		queries: []namedQuery{
+			{name: "max", query: `max(max_over_time(serverless_function_memory_usage_bytes{%[1]s}[%[2]s]))`},
+			{name: "limit", query: `max(serverless_function_memory_limit_bytes{%[1]s})`},
+		},
+	},
+	MetricInstances: {
+		unit: "instances",
+		queries: []namedQuery{
+			{name: "instances", query: `sum(max_over_time(serverless_function_instances{%[1]s}[%[2]s]))`},
+		},
+	},
+}
+
+// Metrics returns the available metric presets.
+func Metrics() []Metric {
+	return []Metric{
+		MetricInvocations,
+		MetricErrors,
+		MetricDuration,
+		MetricColdStarts,
+		MetricMemory,
+		MetricInstances,
+	}
+}
+
+type metricPreset struct {
+	unit    string
+	queries []namedQuery
+}
+
+type namedQuery struct {
+	name  string
+	query string
+}
+
+func durationQuantileQuery(quantile float64) string {
+	return `histogram_quantile(` + strconv.FormatFloat(quantile, 'f', -1, 64) +
+		`, sum by (le) (rate(serverless_function_request_duration_seconds_bucket{%[1]s}[%[2]s])))`
+}
+
+// MetricQuery selects a metric of a function.
+type MetricQuery struct {
+	Metric Metric
+	Start  time.Time
+	End    time.Time
+	// Step is the duration between two points. Counters are aggregated over each step.
+	Step time.Duration
+}
+
+type MetricSeries struct {
+	Metric Metric  `json:"metric"`
+	Name   string  `json:"name"`
+	Unit   string  `json:"unit"`
+	Points []Point `json:"points"`
+}
+
+// promQL returns the named PromQL queries of the metric of the resource.
+func (q MetricQuery) promQL(resourceName string) ([]namedQuery, string, error) {
+	preset, ok := metricPresets[q.Metric]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %q", ErrUnknownMetric, q.Metric)
+	}
+
+	selector := `resource_name=` + strconv.Quote(resourceName)
+	window := strconv.FormatInt(int64(q.Step/time.Second), 10) + "s"
+
+	queries := make([]namedQuery, 0, len(preset.queries))
+	for _, nq := range preset.queries {
+		queries = append(queries, namedQuery{
+			name:  nq.name,
+			query: fmt.Sprintf(nq.query, selector, window),
+		})
+	}
+
+	return queries, preset.unit, nil
+}
@@ -0,0 +1,190 @@
+package cockpit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var ErrMetricsQueryNotSuccessful = errors.New("metrics query was not successful")
+
+type MetricsQueryRangeRequest struct {
+	Query string
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+type Point struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+type Series struct {
+	Labels map[string]string
+	Points []Point
+}
+
+// MetricsClient queries a Prometheus compatible API, such as the Mimir behind the
+// Scaleway metrics data source.
+type MetricsClient interface {
+	// QueryRange evaluates the PromQL query at every step between start and end.
+	QueryRange(ctx context.Context, req MetricsQueryRangeRequest) ([]Series, error)
+}
+
+type prometheusClient struct {
+	httpClient http.Client
+	url        string
+}
+
+func NewPrometheusClient(url string, secretKey string) MetricsClient {
+	return &prometheusClient{
+		httpClient: http.Client{
+			Transport: &roundTripper{
+				base:      http.DefaultTransport,
+				secretKey: secretKey,
+			},
+		},
+		url: url,
+	}
+}
+
+func (c *prometheusClient) QueryRange(ctx context.Context, in MetricsQueryRangeRequest) ([]Series, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		c.url+"/prometheus/api/v1/query_range",
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Add("query", in.Query)
+	q.Add("start", strconv.FormatInt(in.Start.Unix(), 10))
+	q.Add("end", strconv.FormatInt(in.End.Unix(), 10))
+	q.Add("step", strconv.FormatFloat(in.Step.Seconds(), 'f', -1, 64))
+
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("performing request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	var queryResp prometheusQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&queryResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf(
+				"%w: unexpected status code (%d) from Prometheus",
+				ErrMetricsQueryNotSuccessful,
+				resp.StatusCode,
+			)
+		}
+
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	// Unlike Loki, Prometheus explains why an invalid query failed.
+	if queryResp.Status != "success" {
+		return nil, fmt.Errorf(
+			"%w: %s: %s",
+			ErrMetricsQueryNotSuccessful,
+			queryResp.ErrorType,
+			queryResp.Error,
+		)
+	}
+
+	series := make([]Series, 0, len(queryResp.Data.Result))
+
+	for _, result := range queryResp.Data.Result {
+		points := make([]Point, 0, len(result.Values))
+
+		for _, sample := range result.Values {
+			// Such values can't be encoded in JSON, and mean that there was no data
+			// (e.g. a quantile without any request).
+			if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+				continue
+			}
+
+			points = append(points, Point(sample))
+		}
+
+		series = append(series, Series{
+			Labels: result.Metric,
+			Points: points,
+		})
+	}
+
+	return series, nil
+}
+
+type prometheusQueryResponse struct {
+	Status    string                      `json:"status"`
+	ErrorType string                      `json:"errorType"` //nolint:tagliatelle // Prometheus API.
+	Error     string                      `json:"error"`
+	Data      prometheusQueryResponseData `json:"data"`
+}
+
+//nolint:tagliatelle // has to match Prometheus's response structure.
+type prometheusQueryResponseData struct {
+	ResultType string `json:"resultType"`
+	// Range queries always return a matrix.
+	Result []prometheusMatrixSeries `json:"result"`
+}
+
+type prometheusMatrixSeries struct {
+	Metric map[string]string  `json:"metric"`
+	Values []prometheusSample `json:"values"`
+}
+
+type prometheusSample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// UnmarshalJSON decodes a sample, sent as a [<unix seconds>, "<value>"] pair.
+func (s *prometheusSample) UnmarshalJSON(data []byte) error {
+	var pair [2]json.RawMessage
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return fmt.Errorf("decoding sample: %w", err)
+	}
+
+	var (
+		ts    float64
+		value string
+	)
+
+	if err := json.Unmarshal(pair[0], &ts); err != nil {
+		return fmt.Errorf("decoding sample timestamp: %w", err)
+	}
+
+	if err := json.Unmarshal(pair[1], &value); err != nil {
+		return fmt.Errorf("decoding sample value: %w", err)
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("parsing sample value: %w", err)
+	}
+
+	sec, frac := math.Modf(ts)
+	s.Timestamp = time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC()
+	s.Value = v
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package cockpit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusClient_QueryRange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "some-secret-key", r.Header.Get("X-Token"))
+			assert.Equal(t, "/prometheus/api/v1/query_range", r.URL.Path)
+			assert.Equal(t, "up", r.URL.Query().Get("query"))
+			assert.Equal(t, "60", r.URL.Query().Get("start"))
+			assert.Equal(t, "180", r.URL.Query().Get("end"))
+			assert.Equal(t, "60", r.URL.Query().Get("step"))
+
+			_, _ = w.Write([]byte(`{
+				"status": "success",
+				"data": {
+					"resultType": "matrix",
+					"result": [
+						{"metric": {"resource_name": "my-function"}, "values": [[60, "1"], [120.5, "NaN"], [180, "2.5"]]}
+					]
+				}
+			}`))
+		}))
+		t.Cleanup(server.Close)
+
+		series, err := NewPrometheusClient(server.URL, "some-secret-key").QueryRange(t.Context(), MetricsQueryRangeRequest{
+			Query: "up",
+			Start: time.Unix(60, 0),
+			End:   time.Unix(180, 0),
+			Step:  time.Minute,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Series{{
+			Labels: map[string]string{"resource_name": "my-function"},
+			Points: []Point{
+				{Timestamp: time.Unix(60, 0).UTC(), Value: 1},
+				{Timestamp: time.Unix(180, 0).UTC(), Value: 2.5},
+			},
+		}}, series)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status": "error", "errorType": "bad_data", "error": "parse error"}`))
+		}))
+		t.Cleanup(server.Close)
+
+		_, err := NewPrometheusClient(server.URL, "some-secret-key").QueryRange(t.Context(), MetricsQueryRangeRequest{
+			Query: "up{",
+			Start: time.Unix(60, 0),
+			End:   time.Unix(180, 0),
+			Step:  time.Minute,
+		})
+		require.ErrorIs(t, err, ErrMetricsQueryNotSuccessful)
+		assert.ErrorContains(t, err, "parse error")
+	})
+}
+
+func TestMetricQuery_promQL(t *testing.T) {
+	t.Parallel()
+
+	queries, unit, err := MetricQuery{Metric: MetricErrors, Step: 5 * time.Minute}.promQL("my-function")
+	require.NoError(t, err)
+
+	assert.Equal(t, "requests", unit)
+	assert.Equal(t, []namedQuery{
+		{name: "4xx", query: `sum(increase(serverless_function_requests_total{resource_name="my-function", status_code=~"4.."}[300s]))`},
+		{name: "5xx", query: `sum(increase(serverless_function_requests_total{resource_name="my-function", status_code=~"5.."}[300s]))`},
+	}, queries)
+
+	_, _, err = MetricQuery{Metric: "cpu"}.promQL("my-function")
+	require.ErrorIs(t, err, ErrUnknownMetric)
+}
@@ -0,0 +1,147 @@
+package cockpit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/filelock"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// Token is a Cockpit token. Its secret key is only returned when the token is created.
+type Token struct {
+	ID        string    `json:"id"`
+	SecretKey string    `json:"secret_key"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+}
+
+// TokenKey identifies a token: tokens are scoped to a project, and data sources are regional.
+type TokenKey struct {
+	ProjectID string
+	Region    scw.Region
+	Name      string
+}
+
+// TokenStore persists the Cockpit tokens across restarts, so that they don't have to be recreated.
+// It may be shared by several instances of the server. The instances which don't share it create
+// their own tokens, which are left in place: they can't be told apart from the ones still in use.
+type TokenStore interface {
+	// Load returns the stored token, or nil when there is none.
+	Load(key TokenKey) (*Token, error)
+	// CompareAndSwap stores the token if the stored one is still old, which is nil when there was none.
+	// It returns the token stored once done: another instance may have stored its own in the meantime.
+	CompareAndSwap(key TokenKey, old, token *Token) (*Token, error)
+}
+
+type fileTokenStore struct {
+	dir string
+}
+
+// NewFileTokenStore returns a TokenStore keeping each token in a file only readable by the user.
+func NewFileTokenStore(dir string) TokenStore {
+	return &fileTokenStore{dir: dir}
+}
+
+func (s *fileTokenStore) path(key TokenKey) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s_%s_%s.json", key.ProjectID, key.Region, key.Name))
+}
+
+func (s *fileTokenStore) lockPath(key TokenKey) string {
+	return strings.TrimSuffix(s.path(key), ".json") + ".lock"
+}
+
+// Load implements TokenStore.
+func (s *fileTokenStore) Load(key TokenKey) (*Token, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil //nolint:nilnil // no token is not an error.
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading token: %w", err)
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("decoding token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// CompareAndSwap implements TokenStore.
+func (s *fileTokenStore) CompareAndSwap(key TokenKey, old, token *Token) (*Token, error) {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating token directory: %w", err)
+	}
+
+	unlock, err := filelock.Lock(s.lockPath(key))
+	if err != nil {
+		return nil, fmt.Errorf("locking token: %w", err)
+	}
+	defer unlock()
+
+	stored, err := s.Load(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameToken(stored, old) {
+		return stored, nil
+	}
+
+	if err := s.save(key, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *fileTokenStore) save(key TokenKey, token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("encoding token: %w", err)
+	}
+
+	// Written to a temporary file first, so that other instances never read a half-written token.
+	// The temporary file is created with the 0600 permissions.
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(s.path(key))+".*")
+	if err != nil {
+		return fmt.Errorf("creating token file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("writing token file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing token file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("replacing token file: %w", err)
+	}
+
+	return nil
+}
+
+func sameToken(a, b *Token) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	// The creation time is left out, as it loses its monotonic clock reading once stored.
+	return a.ID == b.ID && a.SecretKey == b.SecretKey
+}
@@ -16,17 +16,21 @@ var createAndDeployFunctionNamespaceTool = &mcp.Tool{
 }
 
 // We could embed function.CreateNamespaceRequest but:
-// - The LLM seems to be confused about the `project_id` field which we don't need
-// to set and instead rely on the provider default project.
+// - The LLM seems to be confused about the required `project_id` field, which
+// defaults to the provider default project through the Scope instead.
 type CreateAndDeployFunctionNamespace struct {
+	Scope
+
 	Name string   `json:"name"`
 	Tags []string `json:"tags,omitempty"`
 }
 
 func (in CreateAndDeployFunctionNamespace) ToSDK() *function.CreateNamespaceRequest {
 	return &function.CreateNamespaceRequest{
-		Name: in.Name,
-		Tags: setCreatedByTag(in.Tags),
+		Region:    in.Region,
+		ProjectID: in.ProjectID,
+		Name:      in.Name,
+		Tags:      setCreatedByTag(in.Tags),
 	}
 }
 
@@ -41,6 +45,7 @@ func (t *Tools) CreateAndDeployFunctionNamespace(
 	}
 
 	ns, err = t.functionsAPI.WaitForNamespace(&function.WaitForNamespaceRequest{
+		Region:      ns.Region,
 		NamespaceID: ns.ID,
 	}, scw.WithContext(ctx))
 	if err != nil {
@@ -73,6 +73,7 @@ func TestTools_CreateAndDeployFunctionNamespace(t *testing.T) {
 
 			if tc.givenCreateError == nil {
 				mockFunctionsAPI.EXPECT().WaitForNamespace(&function.WaitForNamespaceRequest{
+					Region:      tc.givenCreatedNamespace.Region,
 					NamespaceID: tc.givenCreatedNamespace.ID,
 				}, mock.Anything).Return(tc.givenCreatedNamespace, tc.givenWaitError)
 			}
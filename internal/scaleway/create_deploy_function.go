@@ -32,13 +32,18 @@ var createAndDeployFunctionTool = &mcp.Tool{
 		  }
 		"""
 
-		The handler in this case would be "handler.handle" (file.function).`,
+		The handler in this case would be "handler.handle" (file.function).
+
+		Functions are public by default. Set "privacy" to "private" for functions that should only be called
+		with a token (see "create_function_token"). Set "http_option" to "redirected" to redirect HTTP to HTTPS.`,
 }
 
 // We could embed function.CreateFunctionRequest but:
 // - It seems the LLM is much better with `namespace_name` than `namespace_id`
 // - The LLM seems to struggle with the `timeout` field which must be a string, but the fancy SDK type confuses it.
 type CreateAndDeployFunctionRequest struct {
+	Scope
+
 	Directory string `json:"directory"`
 
 	// CreateFunctionRequest fields
@@ -54,6 +59,8 @@ type CreateAndDeployFunctionRequest struct {
 	MinScale                   *uint32           `json:"min_scale,omitempty"`
 	MaxScale                   *uint32           `json:"max_scale,omitempty"`
 	MemoryLimit                *uint32           `json:"memory_limit,omitempty"`
+	Privacy                    string            `json:"privacy,omitempty"`
+	HTTPOption                 string            `json:"http_option,omitempty"`
 }
 
 func (req CreateAndDeployFunctionRequest) ToSDK(
@@ -64,6 +71,16 @@ func (req CreateAndDeployFunctionRequest) ToSDK(
 		return nil, fmt.Errorf("parsing timeout: %w", err)
 	}
 
+	privacy, err := parsePrivacy(req.Privacy)
+	if err != nil {
+		return nil, err
+	}
+
+	httpOption, err := parseHTTPOption(req.HTTPOption)
+	if err != nil {
+		return nil, err
+	}
+
 	secrets := make([]*function.Secret, 0, len(req.SecretEnvironmentVariables))
 	for k, v := range req.SecretEnvironmentVariables {
 		secrets = append(secrets, &function.Secret{
@@ -87,6 +104,8 @@ func (req CreateAndDeployFunctionRequest) ToSDK(
 		MinScale:                   req.MinScale,
 		MaxScale:                   req.MaxScale,
 		MemoryLimit:                req.MemoryLimit,
+		Privacy:                    privacy,
+		HTTPOption:                 httpOption,
 	}, nil
 }
 
@@ -95,31 +114,33 @@ func (t *Tools) CreateAndDeployFunction(
 	ctx context.Context,
 	req *mcp.CallToolRequest,
 	in CreateAndDeployFunctionRequest,
-) (*mcp.CallToolResult, Function, error) {
+) (*mcp.CallToolResult, FunctionDeployment, error) {
 	progress := NewFunctionDeploymentProgress(in.FunctionName)
 
-	ns, err := getFunctionNamespaceByName(ctx, t.functionsAPI, in.NamespaceName)
+	ns, err := getFunctionNamespaceByName(ctx, t.functionsAPI, in.Scope, in.NamespaceName)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("getting namespace by name: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("getting namespace by name: %w", err)
 	}
 
 	createReq, err := in.ToSDK(ns.ID)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("converting to SDK request: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("converting to SDK request: %w", err)
 	}
 
+	createReq.Region = ns.Region
+
 	// We always create the function first before zipping the code archive for
 	// faster feedback to the user in case of errors.
 	fun, err := t.functionsAPI.CreateFunction(createReq, scw.WithContext(ctx))
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("creating function: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("creating function: %w", err)
 	}
 
 	progress.NotifyCodeArchiveCreation(ctx, req)
 
 	archive, err := NewCodeArchive(in.Directory)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("creating archive: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("creating archive: %w", err)
 	}
 
 	tags := setCodeArchiveDigestTag(fun.Tags, archive.Digest)
@@ -128,12 +149,13 @@ func (t *Tools) CreateAndDeployFunction(
 	// update the function to add the code archive digest tag (which helps
 	// avoid redeploying the same code in future updates).
 	fun, err = t.functionsAPI.UpdateFunction(&function.UpdateFunctionRequest{
+		Region:     fun.Region,
 		FunctionID: fun.ID,
 		Redeploy:   scw.BoolPtr(false),
 		Tags:       scw.StringsPtr(tags),
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return nil, Function{}, fmt.Errorf(
+		return nil, FunctionDeployment{}, fmt.Errorf(
 			"updating function with code archive digest tag: %w",
 			err,
 		)
@@ -141,34 +163,43 @@ func (t *Tools) CreateAndDeployFunction(
 
 	presignedURLResp, err := t.functionsAPI.GetFunctionUploadURL(
 		&function.GetFunctionUploadURLRequest{
+			Region:        fun.Region,
 			FunctionID:    fun.ID,
 			ContentLength: archive.Size,
 		},
 		scw.WithContext(ctx),
 	)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("getting presigned URL: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("getting presigned URL: %w", err)
 	}
 
 	progress.NotifyCodeUploading(ctx, req)
 
 	if err := archive.Upload(ctx, presignedURLResp.URL); err != nil {
-		return nil, Function{}, fmt.Errorf("uploading archive: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("uploading archive: %w", err)
 	}
 
+	deploymentStartedAt := time.Now()
+
 	_, err = t.functionsAPI.DeployFunction(&function.DeployFunctionRequest{
+		Region:     fun.Region,
 		FunctionID: fun.ID,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("deploying function: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("deploying function: %w", err)
 	}
 
 	progress.NotifyBuildStarted(ctx, req)
 
-	fun, err = waitForFunction(ctx, t.functionsAPI, fun.ID, progress.GetFunctionBuildCB(ctx, req))
+	fun, err = waitForFunction(ctx, t.functionsAPI, fun.Region, fun.ID, progress.GetFunctionBuildCB(ctx, req))
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("waiting for function to be ready: %w", err)
+		return nil, FunctionDeployment{}, fmt.Errorf("waiting for function to be ready: %w", err)
 	}
 
-	return nil, NewFunctionFromSDK(fun), nil
+	t.recordDeployment(ctx, fun, archive)
+
+	return nil, FunctionDeployment{
+		Function:  t.newFunctionWithHostnames(ctx, fun),
+		BuildLogs: t.getBuildLogsOnError(ctx, fun, deploymentStartedAt),
+	}, nil
 }
@@ -0,0 +1,241 @@
+package scaleway
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCronSchedule    = errors.New("invalid cron schedule")
+	ErrUnsupportedTimezone    = errors.New("unsupported timezone")
+	ErrScheduleNotConvertible = errors.New("schedule cannot be converted to UTC")
+)
+
+const (
+	cronMinute = iota
+	cronHour
+	cronDayOfMonth
+	cronMonth
+	cronDayOfWeek
+	cronFieldCount
+)
+
+type cronFieldSpec struct {
+	name     string
+	min, max int
+	aliases  []string
+}
+
+//nolint:gochecknoglobals
+var cronFieldSpecs = [cronFieldCount]cronFieldSpec{
+	cronMinute:     {name: "minute", min: 0, max: 59},
+	cronHour:       {name: "hour", min: 0, max: 23},
+	cronDayOfMonth: {name: "day of month", min: 1, max: 31},
+	cronMonth: {name: "month", min: 1, max: 12, aliases: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}},
+	// Both 0 and 7 are Sunday.
+	cronDayOfWeek: {name: "day of week", min: 0, max: 7, aliases: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}},
+}
+
+// parseCronSchedule validates a schedule in the UNIX cron format ("minute hour day-of-month month day-of-week")
+// and returns its fields.
+func parseCronSchedule(schedule string) ([]string, error) {
+	fields := strings.Fields(schedule)
+	if len(fields) != cronFieldCount {
+		return nil, fmt.Errorf(
+			"%w: %q has %d fields, expected %d (minute hour day-of-month month day-of-week)",
+			ErrInvalidCronSchedule, schedule, len(fields), cronFieldCount,
+		)
+	}
+
+	for i, field := range fields {
+		if _, err := expandCronField(field, cronFieldSpecs[i]); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidCronSchedule, schedule, err)
+		}
+	}
+
+	return fields, nil
+}
+
+// expandCronField returns the values matched by a field, e.g. "1-5/2" gives [1, 3, 5].
+func expandCronField(field string, spec cronFieldSpec) ([]int, error) {
+	var values []int
+
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q in %s field", stepPart, spec.name)
+			}
+		}
+
+		start, end := spec.min, spec.max
+
+		if rangePart != "*" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+
+			start, err = parseCronValue(startPart, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			end = start
+
+			switch {
+			case isRange:
+				end, err = parseCronValue(endPart, spec)
+				if err != nil {
+					return nil, err
+				}
+
+				if end < start {
+					return nil, fmt.Errorf("invalid range %q in %s field", rangePart, spec.name)
+				}
+			case hasStep:
+				// "5/15" means "5-max/15".
+				end = spec.max
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			values = append(values, v)
+		}
+	}
+
+	slices.Sort(values)
+
+	return slices.Compact(values), nil
+}
+
+func parseCronValue(s string, spec cronFieldSpec) (int, error) {
+	if i := slices.Index(spec.aliases, strings.ToLower(s)); i != -1 {
+		// Months start at 1, days of the week at 0.
+		return i + spec.min, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < spec.min || v > spec.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", s, spec.name, spec.min, spec.max)
+	}
+
+	return v, nil
+}
+
+// convertCronScheduleToUTC converts a schedule expressed in the given timezone to UTC,
+// which is the timezone used by Scaleway to run CRON triggers.
+//
+// The current offset of the timezone is used: the schedule will be off by an hour
+// after a daylight saving time change.
+func convertCronScheduleToUTC(schedule, timezone string, now time.Time) (string, error) {
+	fields, err := parseCronSchedule(schedule)
+	if err != nil {
+		return "", err
+	}
+
+	if timezone == "" {
+		return strings.Join(fields, " "), nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrUnsupportedTimezone, timezone, err)
+	}
+
+	_, offsetSeconds := now.In(loc).Zone()
+	if offsetSeconds == 0 {
+		return strings.Join(fields, " "), nil
+	}
+
+	if offsetSeconds%int(time.Hour.Seconds()) != 0 {
+		return "", fmt.Errorf("%w: offset of %q is not a whole number of hours", ErrScheduleNotConvertible, timezone)
+	}
+
+	offsetHours := offsetSeconds / int(time.Hour.Seconds())
+
+	hours, _ := expandCronField(fields[cronHour], cronFieldSpecs[cronHour])
+
+	utcHours := make([]int, 0, len(hours))
+	dayShifts := make(map[int]struct{})
+
+	for _, h := range hours {
+		utc := h - offsetHours
+		// Whether the time moves to the previous or the next day in UTC.
+		dayShift := 0
+
+		switch {
+		case utc < 0:
+			dayShift = -1
+		case utc > 23:
+			dayShift = 1
+		}
+
+		dayShifts[dayShift] = struct{}{}
+		utcHours = append(utcHours, (utc+24)%24)
+	}
+
+	slices.Sort(utcHours)
+	fields[cronHour] = formatCronValues(utcHours, cronFieldSpecs[cronHour])
+
+	if _, sameDay := dayShifts[0]; sameDay && len(dayShifts) == 1 {
+		return strings.Join(fields, " "), nil
+	}
+
+	dayRestricted := fields[cronDayOfMonth] != "*" || fields[cronMonth] != "*" || fields[cronDayOfWeek] != "*"
+	if !dayRestricted {
+		return strings.Join(fields, " "), nil
+	}
+
+	// Some of the hours move to another day in UTC, which may be in another month. Only the days
+	// of the week can be shifted along.
+	if len(dayShifts) > 1 || fields[cronDayOfMonth] != "*" || fields[cronMonth] != "*" {
+		return "", fmt.Errorf(
+			"%w: the schedule crosses midnight in UTC, please provide it in UTC instead",
+			ErrScheduleNotConvertible,
+		)
+	}
+
+	var dayShift int
+	for s := range dayShifts {
+		dayShift = s
+	}
+
+	days, _ := expandCronField(fields[cronDayOfWeek], cronFieldSpecs[cronDayOfWeek])
+
+	utcDays := make([]int, 0, len(days))
+	for _, d := range days {
+		utcDays = append(utcDays, ((d+dayShift)%7+7)%7)
+	}
+
+	slices.Sort(utcDays)
+	fields[cronDayOfWeek] = formatCronValues(slices.Compact(utcDays), cronFieldSpecs[cronDayOfWeek])
+
+	return strings.Join(fields, " "), nil
+}
+
+func formatCronValues(values []int, spec cronFieldSpec) string {
+	if len(values) == spec.max-spec.min+1 {
+		return "*"
+	}
+
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, strconv.Itoa(v))
+	}
+
+	return strings.Join(parts, ",")
+}
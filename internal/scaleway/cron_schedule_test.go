@@ -0,0 +1,138 @@
+package scaleway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCronScheduleToUTC(t *testing.T) {
+	t.Parallel()
+
+	// Central European Summer Time (UTC+2).
+	summer := time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC)
+	// Central European Time (UTC+1).
+	winter := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name      string
+		schedule  string
+		timezone  string
+		now       time.Time
+		want      string
+		wantError error
+	}{
+		{
+			name:     "UTC",
+			schedule: "*/15  9-17 * * mon-fri",
+			want:     "*/15 9-17 * * mon-fri",
+		},
+		{
+			name:     "summer time",
+			schedule: "30 9 * * *",
+			timezone: "Europe/Paris",
+			now:      summer,
+			want:     "30 7 * * *",
+		},
+		{
+			name:     "winter time",
+			schedule: "30 9 * * *",
+			timezone: "Europe/Paris",
+			now:      winter,
+			want:     "30 8 * * *",
+		},
+		{
+			name:     "hours wrap around midnight",
+			schedule: "0 0,12 * * *",
+			timezone: "Europe/Paris",
+			now:      winter,
+			want:     "0 11,23 * * *",
+		},
+		{
+			name:     "day of week moves to the previous day",
+			schedule: "0 1 * * 1,7",
+			timezone: "Europe/Paris",
+			now:      summer,
+			want:     "0 23 * * 0,6",
+		},
+		{
+			name:     "every hour",
+			schedule: "0 * * * *",
+			timezone: "America/New_York",
+			now:      winter,
+			want:     "0 * * * *",
+		},
+		{
+			name:      "day of month crossing midnight",
+			schedule:  "0 0 1 * *",
+			timezone:  "Europe/Paris",
+			now:       winter,
+			wantError: ErrScheduleNotConvertible,
+		},
+		{
+			name:      "month crossing midnight",
+			schedule:  "0 0 * 1 *",
+			timezone:  "Europe/Paris",
+			now:       winter,
+			wantError: ErrScheduleNotConvertible,
+		},
+		{
+			name:     "month without crossing midnight",
+			schedule: "0 9 * 1 *",
+			timezone: "Europe/Paris",
+			now:      winter,
+			want:     "0 8 * 1 *",
+		},
+		{
+			name:      "half-hour offset",
+			schedule:  "0 9 * * *",
+			timezone:  "Asia/Kolkata",
+			now:       winter,
+			wantError: ErrScheduleNotConvertible,
+		},
+		{
+			name:      "unknown timezone",
+			schedule:  "0 9 * * *",
+			timezone:  "Mars/Olympus_Mons",
+			wantError: ErrUnsupportedTimezone,
+		},
+		{
+			name:      "missing field",
+			schedule:  "0 9 * *",
+			wantError: ErrInvalidCronSchedule,
+		},
+		{
+			name:      "out of range",
+			schedule:  "0 24 * * *",
+			wantError: ErrInvalidCronSchedule,
+		},
+		{
+			name:      "invalid step",
+			schedule:  "*/0 * * * *",
+			wantError: ErrInvalidCronSchedule,
+		},
+		{
+			name:      "reversed range",
+			schedule:  "0 17-9 * * *",
+			wantError: ErrInvalidCronSchedule,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := convertCronScheduleToUTC(tc.schedule, tc.timezone, tc.now)
+			if tc.wantError != nil {
+				require.ErrorIs(t, err, tc.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
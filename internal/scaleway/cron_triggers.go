@@ -0,0 +1,327 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const cronScheduleDescription = `The "schedule" uses the UNIX cron format: "minute hour day-of-month month day-of-week".
+		CRON triggers run in UTC. When "timezone" is set (e.g. "Europe/Paris"), the schedule is converted to UTC
+		using the current offset of the timezone: it is not adjusted on daylight saving time changes.`
+
+//nolint:gochecknoglobals
+var (
+	createCronTriggerTool = &mcp.Tool{
+		Name: "create_cron_trigger",
+		Description: `Create a CRON trigger that calls a Scaleway Function on a schedule.
+		It can only be used on functions created by this tool.
+		` + cronScheduleDescription + `
+		The optional "args" JSON object is sent as the body of the request.`,
+	}
+	listCronTriggersTool = &mcp.Tool{
+		Name:        "list_cron_triggers",
+		Description: "List the CRON triggers of a Scaleway Function. Schedules are in UTC.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+	updateCronTriggerTool = &mcp.Tool{
+		Name: "update_cron_trigger",
+		Description: `Update a CRON trigger of a Scaleway Function. Only the provided fields are updated.
+		It can only be used on functions created by this tool.
+		"timezone" applies to the new "schedule", and can't be set without it.
+		` + cronScheduleDescription,
+	}
+	deleteCronTriggerTool = &mcp.Tool{
+		Name:        "delete_cron_trigger",
+		Description: "Delete a CRON trigger of a Scaleway Function. It can only be used on functions created by this tool.",
+	}
+)
+
+type CronTrigger struct {
+	ID         string         `json:"id"`
+	Name       string         `json:"name"`
+	FunctionID string         `json:"function_id"`
+	Schedule   string         `json:"schedule"`
+	Args       map[string]any `json:"args,omitempty"`
+	Status     string         `json:"status"`
+}
+
+func NewCronTriggerFromSDK(c *function.Cron) CronTrigger {
+	trigger := CronTrigger{
+		ID:         c.ID,
+		Name:       c.Name,
+		FunctionID: c.FunctionID,
+		Schedule:   c.Schedule,
+		Status:     c.Status.String(),
+	}
+
+	if c.Args != nil {
+		trigger.Args = *c.Args
+	}
+
+	return trigger
+}
+
+type CreateCronTriggerRequest struct {
+	Scope
+
+	FunctionName string         `json:"function_name"`
+	Name         string         `json:"name"`
+	Schedule     string         `json:"schedule"`
+	Timezone     string         `json:"timezone,omitempty"`
+	Args         map[string]any `json:"args,omitempty"`
+}
+
+func (t *Tools) CreateCronTrigger(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in CreateCronTriggerRequest,
+) (*mcp.CallToolResult, CronTrigger, error) {
+	createReq, err := in.toSDK()
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	trigger, err := t.createCron(ctx, fun, createReq)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	return nil, trigger, nil
+}
+
+// toSDK converts the request, leaving out the function to create the trigger on.
+func (in CreateCronTriggerRequest) toSDK() (*function.CreateCronRequest, error) {
+	schedule, err := convertCronScheduleToUTC(in.Schedule, in.Timezone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	req := &function.CreateCronRequest{
+		Name:     &in.Name,
+		Schedule: schedule,
+	}
+
+	if in.Args != nil {
+		req.Args = (*scw.JSONObject)(&in.Args)
+	}
+
+	return req, nil
+}
+
+func (t *Tools) createCron(
+	ctx context.Context,
+	fun *function.Function,
+	req *function.CreateCronRequest,
+) (CronTrigger, error) {
+	req.Region = fun.Region
+	req.FunctionID = fun.ID
+
+	cron, err := t.functionsAPI.CreateCron(req, scw.WithContext(ctx))
+	if err != nil {
+		return CronTrigger{}, fmt.Errorf("creating cron trigger: %w", err)
+	}
+
+	return NewCronTriggerFromSDK(cron), nil
+}
+
+type ListCronTriggersRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+}
+
+type ListCronTriggersResponse struct {
+	CronTriggers []CronTrigger `json:"cron_triggers"`
+}
+
+func (t *Tools) ListCronTriggers(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in ListCronTriggersRequest,
+) (*mcp.CallToolResult, ListCronTriggersResponse, error) {
+	fun, err := getFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, ListCronTriggersResponse{}, fmt.Errorf("getting function by name: %w", err)
+	}
+
+	crons, err := listCrons(ctx, t.functionsAPI, fun)
+	if err != nil {
+		return nil, ListCronTriggersResponse{}, err
+	}
+
+	triggers := make([]CronTrigger, 0, len(crons))
+	for _, c := range crons {
+		triggers = append(triggers, NewCronTriggerFromSDK(c))
+	}
+
+	return nil, ListCronTriggersResponse{CronTriggers: triggers}, nil
+}
+
+type UpdateCronTriggerRequest struct {
+	Scope
+
+	FunctionName string         `json:"function_name"`
+	Name         string         `json:"name"`
+	NewName      *string        `json:"new_name,omitempty"`
+	Schedule     *string        `json:"schedule,omitempty"`
+	Timezone     string         `json:"timezone,omitempty"`
+	Args         map[string]any `json:"args,omitempty"`
+}
+
+func (t *Tools) UpdateCronTrigger(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in UpdateCronTriggerRequest,
+) (*mcp.CallToolResult, CronTrigger, error) {
+	updateReq, err := in.toSDK()
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	cron, err := getCronByName(ctx, t.functionsAPI, fun, in.Name)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	trigger, err := t.updateCron(ctx, fun, cron, updateReq)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	return nil, trigger, nil
+}
+
+// toSDK converts the request, leaving out the trigger to update.
+func (in UpdateCronTriggerRequest) toSDK() (*function.UpdateCronRequest, error) {
+	// The timezone of the current schedule is unknown, as it is stored in UTC.
+	if in.Timezone != "" && in.Schedule == nil {
+		return nil, fmt.Errorf("%w: \"timezone\" can only be set along with \"schedule\"", ErrInvalidValue)
+	}
+
+	req := &function.UpdateCronRequest{
+		Name: in.NewName,
+	}
+
+	if in.Schedule != nil {
+		schedule, err := convertCronScheduleToUTC(*in.Schedule, in.Timezone, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		req.Schedule = &schedule
+	}
+
+	if in.Args != nil {
+		req.Args = (*scw.JSONObject)(&in.Args)
+	}
+
+	return req, nil
+}
+
+func (t *Tools) updateCron(
+	ctx context.Context,
+	fun *function.Function,
+	cron *function.Cron,
+	req *function.UpdateCronRequest,
+) (CronTrigger, error) {
+	req.Region = fun.Region
+	req.CronID = cron.ID
+
+	cron, err := t.functionsAPI.UpdateCron(req, scw.WithContext(ctx))
+	if err != nil {
+		return CronTrigger{}, fmt.Errorf("updating cron trigger: %w", err)
+	}
+
+	return NewCronTriggerFromSDK(cron), nil
+}
+
+type DeleteCronTriggerRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+	Name         string `json:"name"`
+}
+
+func (t *Tools) DeleteCronTrigger(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in DeleteCronTriggerRequest,
+) (*mcp.CallToolResult, CronTrigger, error) {
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	cron, err := getCronByName(ctx, t.functionsAPI, fun, in.Name)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	trigger, err := t.deleteCron(ctx, fun, cron)
+	if err != nil {
+		return nil, CronTrigger{}, err
+	}
+
+	return nil, trigger, nil
+}
+
+func (t *Tools) deleteCron(ctx context.Context, fun *function.Function, cron *function.Cron) (CronTrigger, error) {
+	cron, err := t.functionsAPI.DeleteCron(&function.DeleteCronRequest{
+		Region: fun.Region,
+		CronID: cron.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return CronTrigger{}, fmt.Errorf("deleting cron trigger: %w", err)
+	}
+
+	return NewCronTriggerFromSDK(cron), nil
+}
+
+func listCrons(ctx context.Context, functionAPI FunctionAPI, fun *function.Function) ([]*function.Cron, error) {
+	resp, err := functionAPI.ListCrons(&function.ListCronsRequest{
+		Region:     fun.Region,
+		FunctionID: fun.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("listing cron triggers: %w", err)
+	}
+
+	return resp.Crons, nil
+}
+
+func getCronByName(
+	ctx context.Context,
+	functionAPI FunctionAPI,
+	fun *function.Function,
+	name string,
+) (*function.Cron, error) {
+	crons, err := listCrons(ctx, functionAPI, fun)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range crons {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: cron trigger %q", ErrResourceNotFound, name)
+}
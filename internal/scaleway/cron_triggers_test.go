@@ -0,0 +1,176 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	someCronID   = "some-cron-id"
+	someCronName = "some-cron"
+)
+
+func TestTools_CreateCronTrigger(t *testing.T) {
+	t.Parallel()
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+	mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+		Name: scw.StringPtr(fixed.SomeFunctionName),
+	}, mock.Anything).Return(&function.ListFunctionsResponse{
+		Functions: []*function.Function{{
+			ID:   fixed.SomeFunctionID,
+			Name: fixed.SomeFunctionName,
+			Tags: []string{constants.TagCreatedByScalewayMCP},
+		}},
+	}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().CreateCron(&function.CreateCronRequest{
+		FunctionID: fixed.SomeFunctionID,
+		Name:       scw.StringPtr(someCronName),
+		Schedule:   "0 6 * * 1-5",
+		Args:       &scw.JSONObject{"report": "daily"},
+	}, mock.Anything).Return(&function.Cron{
+		ID:         someCronID,
+		Name:       someCronName,
+		FunctionID: fixed.SomeFunctionID,
+		Schedule:   "0 6 * * 1-5",
+		Args:       &scw.JSONObject{"report": "daily"},
+		Status:     function.CronStatusCreating,
+	}, nil).Once()
+
+	tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+	_, got, err := tools.CreateCronTrigger(t.Context(), nil, CreateCronTriggerRequest{
+		FunctionName: fixed.SomeFunctionName,
+		Name:         someCronName,
+		Schedule:     "0 6 * * 1-5",
+		Args:         map[string]any{"report": "daily"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, CronTrigger{
+		ID:         someCronID,
+		Name:       someCronName,
+		FunctionID: fixed.SomeFunctionID,
+		Schedule:   "0 6 * * 1-5",
+		Args:       map[string]any{"report": "daily"},
+		Status:     "creating",
+	}, got)
+}
+
+func TestTools_DeleteCronTrigger(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		givenFunction *function.Function
+		givenCrons    []*function.Cron
+		req           DeleteCronTriggerRequest
+		shouldDelete  bool
+		wantError     require.ErrorAssertionFunc
+	}{
+		{
+			name: "disallow deleting trigger of function not owned by tool",
+			givenFunction: &function.Function{
+				ID:   fixed.SomeFunctionID,
+				Name: fixed.SomeFunctionName,
+				// Missing tag that indicates ownership by the tool.
+				Tags: []string{"some-other-tag"},
+			},
+			req: DeleteCronTriggerRequest{
+				FunctionName: fixed.SomeFunctionName,
+				Name:         someCronName,
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrResourceNotOwnedByTool)
+			},
+		},
+		{
+			name: "trigger not found",
+			givenFunction: &function.Function{
+				ID:   fixed.SomeFunctionID,
+				Name: fixed.SomeFunctionName,
+				Tags: []string{constants.TagCreatedByScalewayMCP},
+			},
+			givenCrons: []*function.Cron{{ID: someCronID, Name: "some-other-cron"}},
+			req: DeleteCronTriggerRequest{
+				FunctionName: fixed.SomeFunctionName,
+				Name:         someCronName,
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrResourceNotFound)
+			},
+		},
+		{
+			name: "success",
+			givenFunction: &function.Function{
+				ID:   fixed.SomeFunctionID,
+				Name: fixed.SomeFunctionName,
+				Tags: []string{constants.TagCreatedByScalewayMCP},
+			},
+			givenCrons: []*function.Cron{{ID: someCronID, Name: someCronName}},
+			req: DeleteCronTriggerRequest{
+				FunctionName: fixed.SomeFunctionName,
+				Name:         someCronName,
+			},
+			shouldDelete: true,
+			wantError:    require.NoError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+			mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+				Name: &tc.req.FunctionName,
+			}, mock.Anything).Return(&function.ListFunctionsResponse{
+				Functions: []*function.Function{tc.givenFunction},
+			}, nil).Once()
+
+			if tc.givenCrons != nil {
+				mockFunctionsAPI.EXPECT().ListCrons(&function.ListCronsRequest{
+					FunctionID: tc.givenFunction.ID,
+				}, mock.Anything, mock.Anything).Return(&function.ListCronsResponse{
+					Crons: tc.givenCrons,
+				}, nil).Once()
+			}
+
+			if tc.shouldDelete {
+				mockFunctionsAPI.EXPECT().DeleteCron(&function.DeleteCronRequest{
+					CronID: someCronID,
+				}, mock.Anything).Return(tc.givenCrons[0], nil).Once()
+			}
+
+			tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+			_, _, err := tools.DeleteCronTrigger(t.Context(), nil, tc.req)
+			tc.wantError(t, err)
+		})
+	}
+}
+
+func TestTools_UpdateCronTrigger_TimezoneWithoutSchedule(t *testing.T) {
+	t.Parallel()
+
+	// No API call is expected: the request is rejected before looking the function up.
+	tools := &Tools{functionsAPI: mockscaleway.NewMockFunctionAPI(t)}
+
+	_, _, err := tools.UpdateCronTrigger(t.Context(), nil, UpdateCronTriggerRequest{
+		FunctionName: fixed.SomeFunctionName,
+		Name:         someCronName,
+		Timezone:     "Europe/Paris",
+	})
+	require.ErrorIs(t, err, ErrInvalidValue)
+}
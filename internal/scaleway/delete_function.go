@@ -16,6 +16,8 @@ var deleteFunctionTool = &mcp.Tool{
 }
 
 type DeleteFunctionRequest struct {
+	Scope
+
 	FunctionName string `json:"function_name"`
 }
 
@@ -24,21 +26,31 @@ func (t *Tools) DeleteFunction(
 	_ *mcp.CallToolRequest,
 	in DeleteFunctionRequest,
 ) (*mcp.CallToolResult, Function, error) {
-	fun, err := getFunctionByName(ctx, t.functionsAPI, in.FunctionName)
+	fun, err := getFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
 	if err != nil {
 		return nil, Function{}, fmt.Errorf("getting function by name: %w", err)
 	}
 
-	if err := checkResourceOwnership(fun.Tags); err != nil {
+	deleted, err := t.deleteFunction(ctx, fun)
+	if err != nil {
 		return nil, Function{}, err
 	}
 
-	fun, err = t.functionsAPI.DeleteFunction(&function.DeleteFunctionRequest{
+	return nil, deleted, nil
+}
+
+func (t *Tools) deleteFunction(ctx context.Context, fun *function.Function) (Function, error) {
+	if err := checkResourceOwnership(fun.Tags); err != nil {
+		return Function{}, err
+	}
+
+	fun, err := t.functionsAPI.DeleteFunction(&function.DeleteFunctionRequest{
+		Region:     fun.Region,
 		FunctionID: fun.ID,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("deleting function: %w", err)
+		return Function{}, fmt.Errorf("deleting function: %w", err)
 	}
 
-	return nil, NewFunctionFromSDK(fun), nil
+	return NewFunctionFromSDK(fun), nil
 }
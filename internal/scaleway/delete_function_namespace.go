@@ -16,6 +16,8 @@ var deleteFunctionNamespaceTool = &mcp.Tool{
 }
 
 type DeleteFunctionNamespaceRequest struct {
+	Scope
+
 	NamespaceName string `json:"namespace_name"`
 }
 
@@ -24,7 +26,7 @@ func (t *Tools) DeleteFunctionNamespace(
 	_ *mcp.CallToolRequest,
 	in DeleteFunctionNamespaceRequest,
 ) (*mcp.CallToolResult, Namespace, error) {
-	ns, err := getFunctionNamespaceByName(ctx, t.functionsAPI, in.NamespaceName)
+	ns, err := getFunctionNamespaceByName(ctx, t.functionsAPI, in.Scope, in.NamespaceName)
 	if err != nil {
 		return nil, Namespace{}, fmt.Errorf("getting namespace by name: %w", err)
 	}
@@ -34,6 +36,7 @@ func (t *Tools) DeleteFunctionNamespace(
 	}
 
 	ns, err = t.functionsAPI.DeleteNamespace(&function.DeleteNamespaceRequest{
+		Region:      ns.Region,
 		NamespaceID: ns.ID,
 	}, scw.WithContext(ctx))
 	if err != nil {
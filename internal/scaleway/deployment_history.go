@@ -0,0 +1,324 @@
+package scaleway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/filelock"
+	"github.com/google/uuid"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+)
+
+const (
+	// maxDeploymentsPerFunction bounds the disk space used by the code archives.
+	maxDeploymentsPerFunction = 20
+
+	deploymentHistoryFile = "history.json"
+	deploymentHistoryLock = "history.lock"
+	archivesDir           = "archives"
+)
+
+// DeploymentRecord is a deployment of a function, with everything needed to deploy it again.
+type DeploymentRecord struct {
+	ID                string         `json:"id"`
+	FunctionID        string         `json:"function_id"`
+	FunctionName      string         `json:"function_name"`
+	DeployedAt        time.Time      `json:"deployed_at"`
+	Status            string         `json:"status"`
+	CodeArchiveDigest string         `json:"code_archive_digest"`
+	Config            FunctionConfig `json:"config"`
+}
+
+// FunctionConfig is the configuration of a function which can be redeployed.
+// Secrets are left out, as the API does not return their values.
+type FunctionConfig struct {
+	Runtime              string            `json:"runtime"`
+	Handler              string            `json:"handler"`
+	Timeout              string            `json:"timeout,omitempty"`
+	Description          string            `json:"description,omitempty"`
+	EnvironmentVariables map[string]string `json:"environment_variables,omitempty"`
+	MinScale             uint32            `json:"min_scale"`
+	MaxScale             uint32            `json:"max_scale"`
+	MemoryLimit          uint32            `json:"memory_limit"`
+	Privacy              string            `json:"privacy,omitempty"`
+	HTTPOption           string            `json:"http_option,omitempty"`
+}
+
+func NewFunctionConfigFromSDK(f *function.Function) FunctionConfig {
+	config := FunctionConfig{
+		Runtime:              f.Runtime.String(),
+		Handler:              f.Handler,
+		Description:          valueOrDefault(f.Description, ""),
+		EnvironmentVariables: f.EnvironmentVariables,
+		MinScale:             f.MinScale,
+		MaxScale:             f.MaxScale,
+		MemoryLimit:          f.MemoryLimit,
+		Privacy:              string(f.Privacy),
+		HTTPOption:           string(f.HTTPOption),
+	}
+
+	if f.Timeout != nil {
+		config.Timeout = (time.Duration(f.Timeout.Seconds) * time.Second).String()
+	}
+
+	return config
+}
+
+// ToUpdateRequest returns the request which restores the configuration.
+func (c FunctionConfig) ToUpdateRequest(directory, functionName string) UpdateFunctionRequest {
+	req := UpdateFunctionRequest{
+		Directory:            directory,
+		FunctionName:         functionName,
+		Runtime:              &c.Runtime,
+		Handler:              &c.Handler,
+		Description:          &c.Description,
+		MinScale:             &c.MinScale,
+		MaxScale:             &c.MaxScale,
+		MemoryLimit:          &c.MemoryLimit,
+		EnvironmentVariables: &c.EnvironmentVariables,
+	}
+
+	if c.Timeout != "" {
+		req.Timeout = &c.Timeout
+	}
+
+	if c.Privacy != "" && c.Privacy != string(function.FunctionPrivacyUnknownPrivacy) {
+		req.Privacy = &c.Privacy
+	}
+
+	if c.HTTPOption != "" && c.HTTPOption != string(function.FunctionHTTPOptionUnknownHTTPOption) {
+		req.HTTPOption = &c.HTTPOption
+	}
+
+	if req.EnvironmentVariables != nil && *req.EnvironmentVariables == nil {
+		// Without any environment variables, the existing ones must still be removed.
+		req.EnvironmentVariables = &map[string]string{}
+	}
+
+	return req
+}
+
+// DeploymentHistory keeps the code archives and the configuration of the last deployments
+// of each function on disk:
+//
+//	<dir>/<function_id>/history.json
+//	<dir>/<function_id>/archives/<digest>.zip
+//
+// The directory may be shared by several instances of the server: the updates of the
+// history of a function are serialized with a lock file next to it.
+type DeploymentHistory struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewDeploymentHistory(dir string) *DeploymentHistory {
+	return &DeploymentHistory{dir: dir}
+}
+
+// Record stores the deployment of the function with the given archive.
+func (h *DeploymentHistory) Record(fun *function.Function, archive *CodeArchive) (DeploymentRecord, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	record := DeploymentRecord{
+		ID:                uuid.NewString(),
+		FunctionID:        fun.ID,
+		FunctionName:      fun.Name,
+		DeployedAt:        time.Now().UTC(),
+		Status:            fun.Status.String(),
+		CodeArchiveDigest: archive.Digest,
+		Config:            NewFunctionConfigFromSDK(fun),
+	}
+
+	if err := os.MkdirAll(filepath.Join(h.dir, fun.ID, archivesDir), 0o700); err != nil {
+		return DeploymentRecord{}, fmt.Errorf("creating history directory: %w", err)
+	}
+
+	// Held until the unused archives are removed, so that the archive of a deployment
+	// recorded by another instance is never removed before its record is written.
+	unlock, err := filelock.Lock(filepath.Join(h.dir, fun.ID, deploymentHistoryLock))
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("locking deployment history: %w", err)
+	}
+	defer unlock()
+
+	if err := copyFileIfMissing(archive.Path, h.archivePath(fun.ID, archive.Digest)); err != nil {
+		return DeploymentRecord{}, fmt.Errorf("storing code archive: %w", err)
+	}
+
+	records, err := h.read(fun.ID)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	// Newest first.
+	records = append([]DeploymentRecord{record}, records...)
+	if len(records) > maxDeploymentsPerFunction {
+		records = records[:maxDeploymentsPerFunction]
+	}
+
+	if err := h.write(fun.ID, records); err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	if err := h.removeUnusedArchives(fun.ID, records); err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	return record, nil
+}
+
+// List returns the deployments of the function, newest first.
+func (h *DeploymentHistory) List(functionID string) ([]DeploymentRecord, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.read(functionID)
+}
+
+// CodeArchive returns the stored code archive of the deployment.
+func (h *DeploymentHistory) CodeArchive(record DeploymentRecord) (*CodeArchive, error) {
+	path := h.archivePath(record.FunctionID, record.CodeArchiveDigest)
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("getting stored code archive: %w", err)
+	}
+
+	return &CodeArchive{
+		Path:   path,
+		Size:   safeConvertInt64ToUint64(stat.Size()),
+		Digest: record.CodeArchiveDigest,
+	}, nil
+}
+
+func (h *DeploymentHistory) archivePath(functionID, digest string) string {
+	// Colons are not allowed in file names on Windows.
+	return filepath.Join(h.dir, functionID, archivesDir, strings.ReplaceAll(digest, ":", "-")+".zip")
+}
+
+func (h *DeploymentHistory) read(functionID string) ([]DeploymentRecord, error) {
+	data, err := os.ReadFile(filepath.Join(h.dir, functionID, deploymentHistoryFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading deployment history: %w", err)
+	}
+
+	var records []DeploymentRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("decoding deployment history: %w", err)
+	}
+
+	return records, nil
+}
+
+func (h *DeploymentHistory) write(functionID string, records []DeploymentRecord) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding deployment history: %w", err)
+	}
+
+	// Written to a temporary file first, so that the history is never left half-written.
+	tmp, err := os.CreateTemp(filepath.Join(h.dir, functionID), deploymentHistoryFile+".*")
+	if err != nil {
+		return fmt.Errorf("creating deployment history: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("writing deployment history: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing deployment history: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(h.dir, functionID, deploymentHistoryFile)); err != nil {
+		return fmt.Errorf("replacing deployment history: %w", err)
+	}
+
+	return nil
+}
+
+func (h *DeploymentHistory) removeUnusedArchives(functionID string, records []DeploymentRecord) error {
+	entries, err := os.ReadDir(filepath.Join(h.dir, functionID, archivesDir))
+	if err != nil {
+		return fmt.Errorf("listing stored code archives: %w", err)
+	}
+
+	used := make([]string, 0, len(records))
+	for _, r := range records {
+		used = append(used, filepath.Base(h.archivePath(functionID, r.CodeArchiveDigest)))
+	}
+
+	for _, entry := range entries {
+		if slices.Contains(used, entry.Name()) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(h.dir, functionID, archivesDir, entry.Name())); err != nil {
+			return fmt.Errorf("removing unused code archive: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyFileIfMissing copies the file, unless the destination already exists. Archives are
+// stored by digest, so an existing archive has the same content.
+func copyFileIfMissing(src, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("opening source file: %w", err)
+	}
+
+	defer func() {
+		_ = in.Close()
+	}()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*")
+	if err != nil {
+		return fmt.Errorf("creating destination file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("copying file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("copying file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("renaming destination file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package scaleway
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCodeArchive(t *testing.T, content string) *CodeArchive {
+	t.Helper()
+
+	archive, err := NewCodeArchive(writeTestFiles(t, []testFile{
+		{name: "handler.py", content: content, mode: 0o644},
+	}))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.Remove(archive.Path) })
+
+	return archive
+}
+
+func TestDeploymentHistory_Record(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	history := NewDeploymentHistory(dir)
+
+	oldArchive := newTestCodeArchive(t, "print('old')")
+	newArchive := newTestCodeArchive(t, "print('new')")
+
+	fun := &function.Function{
+		ID:                   fixed.SomeFunctionID,
+		Name:                 fixed.SomeFunctionName,
+		Status:               function.FunctionStatusReady,
+		Runtime:              function.FunctionRuntimePython313,
+		Handler:              "handler.handle",
+		EnvironmentVariables: map[string]string{"LOG_LEVEL": "debug"},
+	}
+
+	first, err := history.Record(fun, oldArchive)
+	require.NoError(t, err)
+
+	// Enough deployments of the new archive to push the first one out of the history.
+	for range maxDeploymentsPerFunction {
+		_, err = history.Record(fun, newArchive)
+		require.NoError(t, err)
+	}
+
+	records, err := history.List(fixed.SomeFunctionID)
+	require.NoError(t, err)
+	require.Len(t, records, maxDeploymentsPerFunction)
+
+	assert.NotContains(t, records, first)
+	assert.Equal(t, newArchive.Digest, records[0].CodeArchiveDigest)
+	assert.Equal(t, FunctionConfig{
+		Runtime:              "python313",
+		Handler:              "handler.handle",
+		EnvironmentVariables: map[string]string{"LOG_LEVEL": "debug"},
+	}, records[0].Config)
+
+	archives, err := os.ReadDir(filepath.Join(dir, fixed.SomeFunctionID, archivesDir))
+	require.NoError(t, err)
+	assert.Len(t, archives, 1, "the archive of the first deployment should be removed")
+
+	stored, err := history.CodeArchive(records[0])
+	require.NoError(t, err)
+	assert.Equal(t, newArchive.Size, stored.Size)
+}
+
+func TestDeploymentHistory_Record_sharedDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	// As if two instances of the server recorded deployments at the same time.
+	histories := []*DeploymentHistory{NewDeploymentHistory(dir), NewDeploymentHistory(dir)}
+
+	archives := []*CodeArchive{newTestCodeArchive(t, "print('a')"), newTestCodeArchive(t, "print('b')")}
+
+	fun := &function.Function{ID: fixed.SomeFunctionID, Name: fixed.SomeFunctionName}
+
+	const recordsPerHistory = maxDeploymentsPerFunction / 2
+
+	var wg sync.WaitGroup
+
+	for i, history := range histories {
+		wg.Go(func() {
+			for range recordsPerHistory {
+				_, err := history.Record(fun, archives[i])
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	records, err := histories[0].List(fixed.SomeFunctionID)
+	require.NoError(t, err)
+	assert.Len(t, records, 2*recordsPerHistory, "no record should be lost")
+
+	for _, record := range records {
+		_, err := histories[0].CodeArchive(record)
+		assert.NoError(t, err, "the archive of every record should be kept")
+	}
+}
+
+func TestSelectRollbackTarget(t *testing.T) {
+	t.Parallel()
+
+	records := []DeploymentRecord{
+		{ID: "current", Status: "ready"},
+		{ID: "failed", Status: "error"},
+		{ID: "previous", Status: "ready"},
+	}
+
+	tt := []struct {
+		name         string
+		records      []DeploymentRecord
+		deploymentID string
+		wantID       string
+		wantError    require.ErrorAssertionFunc
+	}{
+		{
+			name:      "previous ready deployment",
+			records:   records,
+			wantID:    "previous",
+			wantError: require.NoError,
+		},
+		{
+			name:         "given deployment",
+			records:      records,
+			deploymentID: "failed",
+			wantID:       "failed",
+			wantError:    require.NoError,
+		},
+		{
+			name:         "unknown deployment",
+			records:      records,
+			deploymentID: "unknown",
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrResourceNotFound)
+			},
+		},
+		{
+			name:    "no previous deployment",
+			records: records[:1],
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrResourceNotFound)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := selectRollbackTarget(tc.records, tc.deploymentID)
+			tc.wantError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.wantID, got.ID)
+		})
+	}
+}
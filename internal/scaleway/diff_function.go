@@ -0,0 +1,294 @@
+package scaleway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pmezard/go-difflib/difflib"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// maxFileDiffSize keeps the tool result readable when a large file changed.
+	maxFileDiffSize = 32 * 1024
+	// maxDiffsSize bounds the size of all the diffs of the tool result.
+	maxDiffsSize = 256 * 1024
+	// maxDiffedFileSize leaves out larger files, as computing their diff is slow.
+	maxDiffedFileSize = 1024 * 1024
+
+	fileDiffStatusAdded    = "added"
+	fileDiffStatusRemoved  = "removed"
+	fileDiffStatusModified = "modified"
+)
+
+//nolint:gochecknoglobals
+var diffFunctionTool = &mcp.Tool{
+	Name: "diff_function",
+	Description: `Compare a local directory with the code deployed on a Scaleway Function.
+	This shows exactly what "update_function" would change, and should be used before updating a function.
+
+	- Files left out of the code archive (see .gitignore and .scwignore) are not compared.
+	- "diffs" holds a unified diff per changed file, from the deployed code to the local one.
+	  Past a size budget, the remaining files are listed with their status but without their diff.
+	  Binary files and files over 1 MiB are not diffed.`,
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
+}
+
+type DiffFunctionRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+	Directory    string `json:"directory"`
+}
+
+type FileDiff struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	// Diff is left empty once the size budget of the diffs is spent.
+	Diff string `json:"diff,omitempty"`
+}
+
+type DiffFunctionResponse struct {
+	// Added files only exist in the local directory.
+	Added []string `json:"added"`
+	// Removed files only exist in the deployed code.
+	Removed  []string   `json:"removed"`
+	Modified []string   `json:"modified"`
+	Diffs    []FileDiff `json:"diffs"`
+}
+
+func (t *Tools) DiffFunction(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in DiffFunctionRequest,
+) (*mcp.CallToolResult, DiffFunctionResponse, error) {
+	fun, err := getFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, DiffFunctionResponse{}, fmt.Errorf("getting function by name: %w", err)
+	}
+
+	local, err := readCodeArchiveFiles(in.Directory)
+	if err != nil {
+		return nil, DiffFunctionResponse{}, fmt.Errorf("reading local directory: %w", err)
+	}
+
+	url, err := t.functionsAPI.GetFunctionDownloadURL(&function.GetFunctionDownloadURLRequest{
+		Region:     fun.Region,
+		FunctionID: fun.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, DiffFunctionResponse{}, fmt.Errorf("getting function download URL: %w", err)
+	}
+
+	deployedDir, err := os.MkdirTemp("", "function-diff-*")
+	if err != nil {
+		return nil, DiffFunctionResponse{}, fmt.Errorf("creating temp directory: %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(deployedDir)
+	}()
+
+	if err := DownloadAndExtractCodeArchive(ctx, url.URL, deployedDir); err != nil {
+		return nil, DiffFunctionResponse{}, fmt.Errorf("downloading and extracting function: %w", err)
+	}
+
+	deployed, err := readCodeArchiveFiles(deployedDir)
+	if err != nil {
+		return nil, DiffFunctionResponse{}, fmt.Errorf("reading deployed code: %w", err)
+	}
+
+	return nil, diffFiles(deployed, local), nil
+}
+
+type archiveFile struct {
+	mode    os.FileMode
+	content []byte
+}
+
+// readCodeArchiveFiles returns the files which would be part of the code archive of the directory,
+// keyed by slash-separated relative path. Symlinks hold their target as content, like in the archive.
+func readCodeArchiveFiles(dir string) (map[string]archiveFile, error) {
+	// We use os.Root to avoid local inclusion vulnerabilities.
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening directory: %w", err)
+	}
+
+	defer func() {
+		_ = root.Close()
+	}()
+
+	files := make(map[string]archiveFile)
+
+	err = walkCodeArchiveFiles(dir, func(relativePath string, info os.FileInfo) error {
+		var (
+			content []byte
+			err     error
+		)
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			var target string
+
+			target, err = root.Readlink(relativePath)
+			content = []byte(filepath.ToSlash(target))
+		} else {
+			content, err = root.ReadFile(relativePath)
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading %q: %w", relativePath, err)
+		}
+
+		files[filepath.ToSlash(relativePath)] = archiveFile{
+			mode:    info.Mode(),
+			content: content,
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+func diffFiles(deployed, local map[string]archiveFile) DiffFunctionResponse {
+	resp := DiffFunctionResponse{
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{},
+		Diffs:    []FileDiff{},
+	}
+
+	paths := slices.Sorted(maps.Keys(deployed))
+	for path := range local {
+		if _, ok := deployed[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	slices.Sort(paths)
+
+	var diffsSize int
+
+	for _, path := range paths {
+		before, inDeployed := deployed[path]
+		after, inLocal := local[path]
+
+		fileDiff := FileDiff{Path: path}
+
+		switch {
+		case !inDeployed:
+			resp.Added = append(resp.Added, path)
+			fileDiff.Status = fileDiffStatusAdded
+		case !inLocal:
+			resp.Removed = append(resp.Removed, path)
+			fileDiff.Status = fileDiffStatusRemoved
+		case bytes.Equal(before.content, after.content) &&
+			normalizeManifestMode(before.mode) == normalizeManifestMode(after.mode):
+			continue
+		default:
+			resp.Modified = append(resp.Modified, path)
+			fileDiff.Status = fileDiffStatusModified
+		}
+
+		// Once a diff does not fit in the budget, the next ones are not computed at all.
+		if diffsSize < maxDiffsSize {
+			diff := unifiedFileDiff(path, before, inDeployed, after, inLocal)
+
+			if diffsSize+len(diff) <= maxDiffsSize {
+				fileDiff.Diff = diff
+				diffsSize += len(diff)
+			} else {
+				diffsSize = maxDiffsSize
+			}
+		}
+
+		resp.Diffs = append(resp.Diffs, fileDiff)
+	}
+
+	return resp
+}
+
+func unifiedFileDiff(path string, before archiveFile, inDeployed bool, after archiveFile, inLocal bool) string {
+	fromFile, toFile := "a/"+path, "b/"+path
+	if !inDeployed {
+		fromFile = "/dev/null"
+	}
+
+	if !inLocal {
+		toFile = "/dev/null"
+	}
+
+	var header string
+
+	if inDeployed && inLocal && normalizeManifestMode(before.mode) != normalizeManifestMode(after.mode) {
+		header = fmt.Sprintf("old mode %o\nnew mode %o\n",
+			normalizeManifestMode(before.mode), normalizeManifestMode(after.mode))
+	}
+
+	if len(before.content) > maxDiffedFileSize || len(after.content) > maxDiffedFileSize {
+		return header + fmt.Sprintf("Files %s and %s differ (too large to diff)\n", fromFile, toFile)
+	}
+
+	if isBinary(before.content) || isBinary(after.content) {
+		return header + fmt.Sprintf("Binary files %s and %s differ\n", fromFile, toFile)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before.content),
+		B:        splitLines(after.content),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		// Only happens when writing to the buffer fails.
+		return header + fmt.Sprintf("Files %s and %s differ\n", fromFile, toFile)
+	}
+
+	diff = header + diff
+	if len(diff) > maxFileDiffSize {
+		diff = strings.ToValidUTF8(diff[:maxFileDiffSize], "") + "\n... (diff truncated)\n"
+	}
+
+	return diff
+}
+
+// isBinary reports whether the content can't be shown in a diff. Like git, files with
+// a NUL byte near the start are considered binary.
+func isBinary(content []byte) bool {
+	const sniffLen = 8000
+
+	sniff := content[:min(len(content), sniffLen)]
+
+	return bytes.IndexByte(sniff, 0) != -1 || !utf8.Valid(content)
+}
+
+// splitLines splits the content after each newline. Unlike difflib.SplitLines, it does not
+// add an empty line at the end of the content.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	// The diff expects every line to end with a newline.
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
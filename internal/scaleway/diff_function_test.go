@@ -0,0 +1,146 @@
+package scaleway
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_DiffFunction(t *testing.T) {
+	t.Parallel()
+
+	deployed, err := NewCodeArchive(writeTestFiles(t, []testFile{
+		{name: "handler.py", content: "def handle(event, context):\n    return 'hello'\n", mode: 0o644},
+		{name: "utils.py", content: "X = 1\n", mode: 0o644},
+		{name: "run.sh", content: "#!/bin/sh\n", mode: 0o644},
+	}))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.Remove(deployed.Path) })
+
+	localDir := writeTestFiles(t, []testFile{
+		{name: "handler.py", content: "def handle(event, context):\n    return 'world'\n", mode: 0o644},
+		{name: "run.sh", content: "#!/bin/sh\n", mode: 0o755},
+		{name: "config.json", content: "{}\n", mode: 0o644},
+		{name: "logo.png", content: "\x89PNG\x00", mode: 0o644},
+		// Left out of the code archive.
+		{name: ".env", content: "SECRET=1\n", mode: 0o600},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, deployed.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+	mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+		Name: scw.StringPtr(fixed.SomeFunctionName),
+	}, mock.Anything).Return(&function.ListFunctionsResponse{
+		Functions: []*function.Function{{ID: fixed.SomeFunctionID, Name: fixed.SomeFunctionName}},
+	}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().GetFunctionDownloadURL(&function.GetFunctionDownloadURLRequest{
+		FunctionID: fixed.SomeFunctionID,
+	}, mock.Anything).Return(&function.DownloadURL{URL: server.URL}, nil).Once()
+
+	tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+	_, got, err := tools.DiffFunction(t.Context(), nil, DiffFunctionRequest{
+		FunctionName: fixed.SomeFunctionName,
+		Directory:    localDir,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"config.json", "logo.png"}, got.Added)
+	assert.Equal(t, []string{"utils.py"}, got.Removed)
+	assert.Equal(t, []string{"handler.py", "run.sh"}, got.Modified)
+
+	assert.Equal(t, []FileDiff{
+		{
+			Path:   "config.json",
+			Status: "added",
+			Diff:   "--- /dev/null\n+++ b/config.json\n@@ -0,0 +1 @@\n+{}\n",
+		},
+		{
+			Path:   "handler.py",
+			Status: "modified",
+			Diff: "--- a/handler.py\n+++ b/handler.py\n@@ -1,2 +1,2 @@\n" +
+				" def handle(event, context):\n-    return 'hello'\n+    return 'world'\n",
+		},
+		{
+			Path:   "logo.png",
+			Status: "added",
+			Diff:   "Binary files /dev/null and b/logo.png differ\n",
+		},
+		{
+			Path:   "run.sh",
+			Status: "modified",
+			Diff:   "old mode 100644\nnew mode 100755\n",
+		},
+		{
+			Path:   "utils.py",
+			Status: "removed",
+			Diff:   "--- a/utils.py\n+++ /dev/null\n@@ -1 +0,0 @@\n-X = 1\n",
+		},
+	}, got.Diffs)
+}
+
+func TestDiffFiles_budget(t *testing.T) {
+	t.Parallel()
+
+	deployed := map[string]archiveFile{
+		"big.txt": {mode: 0o644, content: []byte(strings.Repeat("a\n", maxDiffedFileSize))},
+	}
+
+	local := map[string]archiveFile{
+		"big.txt": {mode: 0o644, content: []byte(strings.Repeat("b\n", maxDiffedFileSize))},
+	}
+
+	// Each diff is close to the size limit of a file diff, so that they don't all fit in the budget.
+	const addedFiles = 2 * maxDiffsSize / maxFileDiffSize
+	for i := range addedFiles {
+		local[fmt.Sprintf("file-%02d.txt", i)] = archiveFile{
+			mode:    0o644,
+			content: []byte(strings.Repeat("x\n", maxFileDiffSize/3)),
+		}
+	}
+
+	got := diffFiles(deployed, local)
+
+	assert.Len(t, got.Added, addedFiles)
+	assert.Equal(t, []string{"big.txt"}, got.Modified)
+	require.Len(t, got.Diffs, addedFiles+1)
+
+	var diffsSize, withoutDiff int
+
+	for _, d := range got.Diffs {
+		assert.NotEmpty(t, d.Status)
+
+		diffsSize += len(d.Diff)
+		if d.Diff == "" {
+			withoutDiff++
+		}
+	}
+
+	assert.LessOrEqual(t, diffsSize, maxDiffsSize)
+	assert.Positive(t, withoutDiff, "the diffs past the budget should be left out")
+	assert.Empty(t, got.Diffs[len(got.Diffs)-1].Diff)
+
+	assert.Equal(t, FileDiff{
+		Path:   "big.txt",
+		Status: "modified",
+		Diff:   "Files a/big.txt and b/big.txt differ (too large to diff)\n",
+	}, got.Diffs[0])
+}
@@ -0,0 +1,70 @@
+package scaleway
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io/fs"
+	"slices"
+)
+
+// Normalized modes used in the manifest, in the same spirit as git:
+// only the file type and the executable bit are relevant. Other permission bits
+// depend on the umask of the machine and would make the digest unstable.
+const (
+	manifestModeFile       = 0o100644
+	manifestModeExecutable = 0o100755
+	manifestModeSymlink    = 0o120000
+)
+
+// contentManifest computes a digest of the content of a code archive, independently
+// of the zip format itself (compression, timestamps, order of the entries, etc.).
+//
+// The digest is the sha256 of the manifest, which lists for each file
+// its normalized mode, the sha256 of its content and its path, sorted by path.
+// For symlinks, the content is the target of the link.
+type contentManifest struct {
+	entries []manifestEntry
+}
+
+type manifestEntry struct {
+	path string
+	mode uint32
+	hash []byte
+}
+
+// add records a file of the archive. The hash must hold the content that was written.
+func (m *contentManifest) add(slashPath string, mode fs.FileMode, contentHash hash.Hash) {
+	m.entries = append(m.entries, manifestEntry{
+		path: slashPath,
+		mode: normalizeManifestMode(mode),
+		hash: contentHash.Sum(nil),
+	})
+}
+
+func (m *contentManifest) digest() string {
+	slices.SortFunc(m.entries, func(a, b manifestEntry) int {
+		return cmp.Compare(a.path, b.path)
+	})
+
+	h := sha256.New()
+
+	for _, e := range m.entries {
+		// Paths can't contain NUL bytes, which makes the encoding unambiguous.
+		_, _ = fmt.Fprintf(h, "%o %x %s\x00", e.mode, e.hash, e.path)
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
+
+func normalizeManifestMode(mode fs.FileMode) uint32 {
+	switch {
+	case mode&fs.ModeSymlink != 0:
+		return manifestModeSymlink
+	case mode.Perm()&0o111 != 0:
+		return manifestModeExecutable
+	default:
+		return manifestModeFile
+	}
+}
@@ -0,0 +1,152 @@
+package scaleway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFile struct {
+	name    string
+	content string
+	mode    os.FileMode
+}
+
+func writeTestFiles(t *testing.T, files []testFile) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(f.content), f.mode))
+		// WriteFile is subject to the umask.
+		require.NoError(t, os.Chmod(path, f.mode))
+	}
+
+	return dir
+}
+
+func archiveDigest(t *testing.T, dir string) string {
+	t.Helper()
+
+	archive, err := NewCodeArchive(dir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.Remove(archive.Path) })
+
+	return archive.Digest
+}
+
+func TestNewCodeArchive_Digest(t *testing.T) {
+	t.Parallel()
+
+	base := []testFile{
+		{name: "handler.py", content: "def handle(event, context): pass", mode: 0o644},
+		{name: "lib/utils.py", content: "X = 1", mode: 0o644},
+		{name: "bin/bootstrap", content: "\x7fELF", mode: 0o755},
+	}
+
+	tt := []struct {
+		name       string
+		files      []testFile
+		wantSameAs bool
+	}{
+		{
+			name: "files created in another order",
+			files: []testFile{
+				base[2],
+				base[1],
+				base[0],
+			},
+			wantSameAs: true,
+		},
+		{
+			name: "different group and other permissions",
+			files: []testFile{
+				{name: "handler.py", content: base[0].content, mode: 0o664},
+				base[1],
+				{name: "bin/bootstrap", content: base[2].content, mode: 0o775},
+			},
+			wantSameAs: true,
+		},
+		{
+			name: "content change",
+			files: []testFile{
+				{name: "handler.py", content: "def handle(event, context): return 1", mode: 0o644},
+				base[1],
+				base[2],
+			},
+			wantSameAs: false,
+		},
+		{
+			name: "executable bit change",
+			files: []testFile{
+				base[0],
+				base[1],
+				{name: "bin/bootstrap", content: base[2].content, mode: 0o644},
+			},
+			wantSameAs: false,
+		},
+		{
+			name: "file renamed",
+			files: []testFile{
+				base[0],
+				{name: "lib/helpers.py", content: base[1].content, mode: 0o644},
+				base[2],
+			},
+			wantSameAs: false,
+		},
+	}
+
+	want := archiveDigest(t, writeTestFiles(t, base))
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, want)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := archiveDigest(t, writeTestFiles(t, tc.files))
+
+			if tc.wantSameAs {
+				assert.Equal(t, want, got)
+			} else {
+				assert.NotEqual(t, want, got)
+			}
+		})
+	}
+}
+
+func TestNewCodeArchive_DigestIgnoresTimestamps(t *testing.T) {
+	t.Parallel()
+
+	dir := writeTestFiles(t, []testFile{
+		{name: "handler.py", content: "def handle(event, context): pass", mode: 0o644},
+	})
+
+	before := archiveDigest(t, dir)
+
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "handler.py"), later, later))
+
+	assert.Equal(t, before, archiveDigest(t, dir))
+}
+
+func TestContentManifest_Digest(t *testing.T) {
+	t.Parallel()
+
+	// The digest is stored in the function tags: it must not change between releases,
+	// otherwise every function would be redeployed on the next update.
+	dir := writeTestFiles(t, []testFile{
+		{name: "handler.py", content: "def handle(event, context): pass", mode: 0o644},
+		{name: "bin/bootstrap", content: "\x7fELF", mode: 0o755},
+	})
+	require.NoError(t, os.Symlink(filepath.Join("bin", "bootstrap"), filepath.Join(dir, "run")))
+
+	assert.Equal(t, "sha256:cf58200787acfc85dfca6275ac60beaa5440ae69987f66587595c2e525eb98fa", archiveDigest(t, dir))
+}
@@ -14,9 +14,15 @@ var downloadFunctionTool = &mcp.Tool{
 	Name: "download_function",
 	Description: `Download the code of a Scaleway Function.
 	The provided "to_directory" must be an existing directory where the function code will be extracted.`,
+	Annotations: &mcp.ToolAnnotations{
+		// Only the local filesystem is written to, Scaleway resources are left untouched.
+		ReadOnlyHint: true,
+	},
 }
 
 type DownloadFunctionRequest struct {
+	Scope
+
 	FunctionName string `json:"function_name"`
 	ToDirectory  string `json:"to_directory"`
 }
@@ -26,12 +32,13 @@ func (t *Tools) DownloadFunction(
 	_ *mcp.CallToolRequest,
 	in DownloadFunctionRequest,
 ) (*mcp.CallToolResult, Function, error) {
-	fun, err := getFunctionByName(ctx, t.functionsAPI, in.FunctionName)
+	fun, err := getFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
 	if err != nil {
 		return nil, Function{}, fmt.Errorf("getting function by name: %w", err)
 	}
 
 	url, err := t.functionsAPI.GetFunctionDownloadURL(&function.GetFunctionDownloadURLRequest{
+		Region:     fun.Region,
 		FunctionID: fun.ID,
 	}, scw.WithContext(ctx))
 	if err != nil {
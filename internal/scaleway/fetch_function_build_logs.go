@@ -0,0 +1,187 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// maxBuildLogsInDeploymentResult is the number of build log lines attached to the result of
+// a failed deployment. Build errors (e.g. from pip or npm) are usually at the end of the output.
+const maxBuildLogsInDeploymentResult = 100
+
+//nolint:gochecknoglobals
+var fetchFunctionBuildLogsTool = &mcp.Tool{
+	Name: "fetch_function_build_logs",
+	Description: `Fetch build logs for a specific Scaleway Function.
+	Build logs contain the output of the function build (e.g. installing dependencies with pip or npm).
+	This is useful to understand why a deployment ended in an error state.`,
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
+}
+
+type FetchFunctionBuildLogsRequest struct {
+	Scope
+
+	FunctionName string    `json:"function_name"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+type FetchFunctionBuildLogsResponse struct {
+	Logs []cockpit.Log `json:"logs"`
+}
+
+func (t *Tools) FetchFunctionBuildLogs(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	req FetchFunctionBuildLogsRequest,
+) (*mcp.CallToolResult, FetchFunctionBuildLogsResponse, error) {
+	cockpitClient, resourceName, err := t.getFunctionCockpit(ctx, req.Scope, req.FunctionName)
+	if err != nil {
+		return nil, FetchFunctionBuildLogsResponse{}, err
+	}
+
+	logs, err := cockpitClient.ListFunctionBuildLogs(
+		ctx,
+		resourceName,
+		req.StartTime,
+		req.EndTime,
+	)
+	if err != nil {
+		return nil, FetchFunctionBuildLogsResponse{}, fmt.Errorf(
+			"listing function build logs: %w",
+			err,
+		)
+	}
+
+	return nil, FetchFunctionBuildLogsResponse{Logs: logs}, nil
+}
+
+// getBuildLogsOnError fetches the build logs of a function which ended its deployment in an error
+// state. It returns nil if the function is not in an error state.
+// Because build logs are only a debugging aid, failing to fetch them is not considered an error.
+func (t *Tools) getBuildLogsOnError(
+	ctx context.Context,
+	fun *function.Function,
+	deploymentStartedAt time.Time,
+) []cockpit.Log {
+	if fun.Status != function.FunctionStatusError {
+		return nil
+	}
+
+	logger := slogctx.FromContext(ctx).With("function_name", fun.Name)
+
+	ns, err := t.functionsAPI.GetNamespace(&function.GetNamespaceRequest{
+		Region:      fun.Region,
+		NamespaceID: fun.NamespaceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		logger.WarnContext(ctx, "Could not get namespace to fetch build logs", "error", err)
+
+		return nil
+	}
+
+	logs, err := t.getCockpitClient(ns.ProjectID, ns.Region).ListFunctionBuildLogs(
+		ctx,
+		cockpitResourceName(fun),
+		deploymentStartedAt,
+		time.Now(),
+	)
+	if err != nil {
+		logger.WarnContext(ctx, "Could not fetch build logs", "error", err)
+
+		return nil
+	}
+
+	if len(logs) > maxBuildLogsInDeploymentResult {
+		logs = logs[len(logs)-maxBuildLogsInDeploymentResult:]
+	}
+
+	return logs
+}
+
+// followBuildLogs returns a callback which calls next, then sends the build logs written since the
+// deployment started to onLog as they arrive. As with getBuildLogsOnError, failing to fetch them
+// is not considered an error: they are not followed anymore.
+func (t *Tools) followBuildLogs(
+	ctx context.Context,
+	fun *function.Function,
+	deploymentStartedAt time.Time,
+	onLog LogTailCallback,
+	next WaitForFunctionCallback,
+) WaitForFunctionCallback {
+	logger := slogctx.FromContext(ctx).With("function_name", fun.Name)
+
+	var (
+		cockpitClient cockpit.Client
+		failed        bool
+		polledUntil   = deploymentStartedAt
+		// seen holds the logs received in the window of the last poll, which may be received again.
+		seen = make(map[tailedLog]struct{})
+	)
+
+	return func(f *function.Function) {
+		next(f)
+
+		if failed {
+			return
+		}
+
+		if cockpitClient == nil {
+			ns, err := t.functionsAPI.GetNamespace(&function.GetNamespaceRequest{
+				Region:      fun.Region,
+				NamespaceID: fun.NamespaceID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				logger.WarnContext(ctx, "Could not get namespace to follow build logs", "error", err)
+
+				failed = true
+
+				return
+			}
+
+			cockpitClient = t.getCockpitClient(ns.ProjectID, ns.Region)
+		}
+
+		// The logs take a while to be ingested: the end of the last window is polled again.
+		start, end := polledUntil.Add(-t.tailIngestionDelay), time.Now()
+		if start.Before(deploymentStartedAt) {
+			start = deploymentStartedAt
+		}
+
+		logs, err := cockpitClient.ListFunctionBuildLogs(ctx, cockpitResourceName(fun), start, end)
+		if err != nil {
+			logger.WarnContext(ctx, "Could not follow build logs", "error", err)
+
+			failed = true
+
+			return
+		}
+
+		polledUntil = end
+
+		maps.DeleteFunc(seen, func(l tailedLog, _ struct{}) bool {
+			return l.timestamp < start.UnixNano()
+		})
+
+		for _, log := range logs {
+			key := tailedLog{timestamp: log.Timestamp.UnixNano(), message: log.Message}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			onLog(log)
+		}
+	}
+}
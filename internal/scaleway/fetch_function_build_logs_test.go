@@ -0,0 +1,216 @@
+package scaleway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockcockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_FetchFunctionBuildLogs(t *testing.T) {
+	t.Parallel()
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+	mockCockpitClient := mockcockpit.NewMockClient(t)
+
+	givenFunction := &function.Function{
+		ID:          fixed.SomeFunctionID,
+		Name:        fixed.SomeFunctionName,
+		NamespaceID: fixed.SomeNamespaceID,
+		DomainName:  "my-function-xyz.functions.fr-par.scw.cloud",
+	}
+
+	wantLogs := []cockpit.Log{
+		{Timestamp: fixed.SomeTimestampA, Message: "Collecting requests"},
+		{Timestamp: fixed.SomeTimestampB, Message: "ERROR: No matching distribution found"},
+	}
+
+	mockFunctionsAPI.EXPECT().ListFunctions(mock.Anything, mock.Anything).Return(
+		&function.ListFunctionsResponse{
+			Functions: []*function.Function{givenFunction},
+		},
+		nil,
+	).Once()
+
+	mockFunctionsAPI.EXPECT().
+		GetNamespace(&function.GetNamespaceRequest{
+			NamespaceID: fixed.SomeNamespaceID,
+		}, mock.Anything).
+		Return(&function.Namespace{
+			ID:        fixed.SomeNamespaceID,
+			ProjectID: fixed.SomeProjectID,
+		}, nil).
+		Once()
+
+	mockCockpitClient.EXPECT().
+		ListFunctionBuildLogs(
+			mock.Anything,
+			"my-function-xyz",
+			fixed.SomeTimestampA,
+			fixed.SomeTimestampB,
+		).
+		Return(wantLogs, nil).
+		Once()
+
+	tools := &Tools{
+		functionsAPI:     mockFunctionsAPI,
+		newCockpitClient: func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+	}
+
+	_, resp, err := tools.FetchFunctionBuildLogs(t.Context(), nil, FetchFunctionBuildLogsRequest{
+		FunctionName: fixed.SomeFunctionName,
+		StartTime:    fixed.SomeTimestampA,
+		EndTime:      fixed.SomeTimestampB,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, FetchFunctionBuildLogsResponse{Logs: wantLogs}, resp)
+}
+
+func TestTools_getBuildLogsOnError(t *testing.T) {
+	t.Parallel()
+
+	manyLogs := make([]cockpit.Log, maxBuildLogsInDeploymentResult+10)
+	for i := range manyLogs {
+		manyLogs[i] = cockpit.Log{Timestamp: fixed.SomeTimestampA, Message: "line"}
+	}
+
+	manyLogs[len(manyLogs)-1].Message = "ERROR: build failed"
+
+	tt := []struct {
+		name          string
+		givenFunction *function.Function
+		givenLogs     []cockpit.Log
+		wantLogs      []cockpit.Log
+	}{
+		{
+			name: "function is ready",
+			givenFunction: &function.Function{
+				ID:     fixed.SomeFunctionID,
+				Status: function.FunctionStatusReady,
+			},
+			wantLogs: nil,
+		},
+		{
+			name: "function is in error, logs are truncated to the last lines",
+			givenFunction: &function.Function{
+				ID:          fixed.SomeFunctionID,
+				Name:        fixed.SomeFunctionName,
+				NamespaceID: fixed.SomeNamespaceID,
+				DomainName:  "my-function-xyz.functions.fr-par.scw.cloud",
+				Status:      function.FunctionStatusError,
+			},
+			givenLogs: manyLogs,
+			wantLogs:  manyLogs[10:],
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+			mockCockpitClient := mockcockpit.NewMockClient(t)
+
+			if tc.givenLogs != nil {
+				mockFunctionsAPI.EXPECT().
+					GetNamespace(mock.Anything, mock.Anything).
+					Return(&function.Namespace{
+						ID:        fixed.SomeNamespaceID,
+						ProjectID: fixed.SomeProjectID,
+					}, nil).
+					Once()
+
+				mockCockpitClient.EXPECT().
+					ListFunctionBuildLogs(
+						mock.Anything,
+						"my-function-xyz",
+						fixed.SomeTimestampA,
+						mock.Anything,
+					).
+					Return(tc.givenLogs, nil).
+					Once()
+			}
+
+			tools := &Tools{
+				functionsAPI:     mockFunctionsAPI,
+				newCockpitClient: func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+			}
+
+			got := tools.getBuildLogsOnError(t.Context(), tc.givenFunction, fixed.SomeTimestampA)
+
+			assert.Equal(t, tc.wantLogs, got)
+		})
+	}
+}
+
+func TestTools_followBuildLogs(t *testing.T) {
+	t.Parallel()
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+	mockCockpitClient := mockcockpit.NewMockClient(t)
+
+	givenFunction := &function.Function{
+		ID:          fixed.SomeFunctionID,
+		Name:        fixed.SomeFunctionName,
+		NamespaceID: fixed.SomeNamespaceID,
+		DomainName:  "my-function-xyz.functions.fr-par.scw.cloud",
+	}
+
+	collecting := cockpit.Log{Timestamp: fixed.SomeTimestampA.Add(time.Second), Message: "Collecting requests"}
+	installing := cockpit.Log{Timestamp: fixed.SomeTimestampA.Add(2 * time.Second), Message: "Installing requests"}
+
+	// The namespace is only looked up once.
+	mockFunctionsAPI.EXPECT().
+		GetNamespace(mock.Anything, mock.Anything).
+		Return(&function.Namespace{
+			ID:        fixed.SomeNamespaceID,
+			ProjectID: fixed.SomeProjectID,
+		}, nil).
+		Once()
+
+	mockCockpitClient.EXPECT().
+		ListFunctionBuildLogs(mock.Anything, "my-function-xyz", fixed.SomeTimestampA, mock.Anything).
+		Return([]cockpit.Log{collecting}, nil).
+		Once()
+
+	// The logs of the previous poll are received again, along with the new ones.
+	mockCockpitClient.EXPECT().
+		ListFunctionBuildLogs(mock.Anything, "my-function-xyz", fixed.SomeTimestampA, mock.Anything).
+		Return([]cockpit.Log{collecting, installing}, nil).
+		Once()
+
+	tools := &Tools{
+		functionsAPI:     mockFunctionsAPI,
+		newCockpitClient: func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+		// Long enough for the second poll to go back to the start of the deployment.
+		tailIngestionDelay: 100 * 365 * 24 * time.Hour,
+	}
+
+	var (
+		got   []cockpit.Log
+		polls int
+	)
+
+	cb := tools.followBuildLogs(
+		t.Context(),
+		givenFunction,
+		fixed.SomeTimestampA,
+		func(log cockpit.Log) { got = append(got, log) },
+		func(*function.Function) { polls++ },
+	)
+
+	cb(givenFunction)
+	cb(givenFunction)
+
+	assert.Equal(t, 2, polls)
+	assert.Equal(t, []cockpit.Log{collecting, installing}, got)
+}
@@ -2,34 +2,63 @@ package scaleway
 
 import (
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
-	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
 )
 
-var ErrMultipleProjectsNotSupported = errors.New(
-	"fetching logs across multiple Scaleway projects is not supported yet",
+const (
+	defaultLogsLimit = 100
+	maxLogsLimit     = 1000
 )
 
 //nolint:gochecknoglobals
-var fetchFunctionLogsTool = &mcp.Tool{
-	Name:        "fetch_function_logs",
-	Description: "Fetch logs for a specific Scaleway Function",
-}
+var (
+	logLevels = []string{"debug", "info", "warning", "warn", "error", "critical", "fatal"}
+
+	fetchFunctionLogsTool = &mcp.Tool{
+		Name: "fetch_function_logs",
+		Description: `Fetch logs for a specific Scaleway Function.
+
+		- "contains" and "regexp" (RE2 syntax) filter the log messages, and "level" the log level (e.g. "error").
+		- "limit" defaults to 100 logs, and cannot exceed 1000.
+		- "direction" is either "backward" (default: newest logs first) or "forward" (oldest logs first).
+		  When there are more logs than the limit, the newest ones are returned when going backward.
+		- When "next_cursor" is set in the result, more logs are available: call the tool again
+		  with the same arguments and this cursor to get the next page.`,
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+)
 
 type FetchFunctionLogsRequest struct {
+	Scope
+
 	FunctionName string    `json:"function_name"`
 	StartTime    time.Time `json:"start_time"`
 	EndTime      time.Time `json:"end_time"`
+
+	Contains  string `json:"contains,omitempty"`
+	Regexp    string `json:"regexp,omitempty"`
+	Level     string `json:"level,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	Cursor    string `json:"cursor,omitempty"`
 }
 
 type FetchFunctionLogsResponse struct {
-	Logs []cockpit.Log `json:"logs"`
+	Logs       []cockpit.Log `json:"logs"`
+	NextCursor string        `json:"next_cursor,omitempty"`
 }
 
 func (t *Tools) FetchFunctionLogs(
@@ -37,41 +66,231 @@ func (t *Tools) FetchFunctionLogs(
 	_ *mcp.CallToolRequest,
 	req FetchFunctionLogsRequest,
 ) (*mcp.CallToolResult, FetchFunctionLogsResponse, error) {
-	logger := slogctx.FromContext(ctx)
+	query, err := req.toLogQuery()
+	if err != nil {
+		return nil, FetchFunctionLogsResponse{}, err
+	}
 
-	function, ns, err := getFunctionAndNamespaceByFunctionName(
-		ctx,
-		t.functionsAPI,
-		req.FunctionName,
-	)
+	cursor, err := parseLogsCursor(req.Cursor, query)
 	if err != nil {
-		return nil, FetchFunctionLogsResponse{}, fmt.Errorf("getting function by name: %w", err)
+		return nil, FetchFunctionLogsResponse{}, err
 	}
 
-	if ns.ProjectID != t.projectID {
-		logger.WarnContext(
-			ctx,
-			"fetching logs across multiple Scaleway projects is not supported yet",
-			"function_project_id", ns.ProjectID,
-			"active_profile_project_id", t.projectID,
-		)
+	cockpitClient, resourceName, err := t.getFunctionCockpit(ctx, req.Scope, req.FunctionName)
+	if err != nil {
+		return nil, FetchFunctionLogsResponse{}, err
+	}
+
+	limit := query.Limit
+	query = cursor.apply(query)
+
+	logs, err := cockpitClient.ListFunctionLogs(ctx, resourceName, query)
+	if err != nil {
+		return nil, FetchFunctionLogsResponse{}, fmt.Errorf("listing function logs: %w", err)
+	}
+
+	logs = cursor.skipSeen(logs)
+	if len(logs) > limit {
+		logs = logs[:limit]
+	}
+
+	resp := FetchFunctionLogsResponse{Logs: logs}
+
+	// A full page means that there may be more logs in the window.
+	if len(logs) == limit {
+		resp.NextCursor = cursor.advance(logs).String()
+	}
 
-		return nil, FetchFunctionLogsResponse{}, fmt.Errorf(
-			"%w: function %q is in project %q, but the active Scaleway profile is in project %q",
-			ErrMultipleProjectsNotSupported,
-			req.FunctionName,
-			ns.ProjectID,
-			t.projectID,
+	return nil, resp, nil
+}
+
+func (req FetchFunctionLogsRequest) toLogQuery() (cockpit.LogQuery, error) {
+	query := cockpit.LogQuery{
+		Start:     req.StartTime,
+		End:       req.EndTime,
+		Limit:     req.Limit,
+		Direction: cockpit.Direction(strings.ToLower(req.Direction)),
+		Contains:  req.Contains,
+		Regexp:    req.Regexp,
+		Level:     strings.ToLower(req.Level),
+	}
+
+	switch {
+	case query.Limit == 0:
+		query.Limit = defaultLogsLimit
+	case query.Limit < 0 || query.Limit > maxLogsLimit:
+		return cockpit.LogQuery{}, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidValue, maxLogsLimit)
+	}
+
+	switch query.Direction {
+	case "":
+		query.Direction = cockpit.DirectionBackward
+	case cockpit.DirectionBackward, cockpit.DirectionForward:
+	default:
+		return cockpit.LogQuery{}, fmt.Errorf("%w: unknown direction %q", ErrInvalidValue, req.Direction)
+	}
+
+	if query.Level != "" && !slices.Contains(logLevels, query.Level) {
+		return cockpit.LogQuery{}, fmt.Errorf("%w: unknown level %q, expected one of: %s",
+			ErrInvalidValue, req.Level, strings.Join(logLevels, ", "))
+	}
+
+	if _, err := regexp.Compile(query.Regexp); err != nil {
+		return cockpit.LogQuery{}, fmt.Errorf("%w: invalid regexp: %w", ErrInvalidValue, err)
+	}
+
+	return query, nil
+}
+
+// logsCursor is where the previous page of logs ended: the timestamp of its last log,
+// and the number of logs with this timestamp which were returned. The direction and the
+// filters of the query are kept, as the cursor means nothing for other ones.
+type logsCursor struct {
+	timestamp time.Time
+	seen      int
+	direction cockpit.Direction
+	filters   string
+}
+
+func newLogsCursor(logs []cockpit.Log) logsCursor {
+	last := logs[len(logs)-1].Timestamp
+
+	seen := 0
+
+	for _, log := range slices.Backward(logs) {
+		if !log.Timestamp.Equal(last) {
+			break
+		}
+
+		seen++
+	}
+
+	return logsCursor{timestamp: last, seen: seen}
+}
+
+// parseLogsCursor parses the cursor returned with the previous page of the query.
+// Without a cursor, the returned one is at the start of the query.
+func parseLogsCursor(s string, query cockpit.LogQuery) (logsCursor, error) {
+	cursor := logsCursor{direction: query.Direction, filters: hashLogsFilters(query)}
+	if s == "" {
+		return cursor, nil
+	}
+
+	parts := strings.Split(s, "-")
+	if len(parts) != 4 {
+		return logsCursor{}, fmt.Errorf("%w: invalid cursor %q", ErrInvalidValue, s)
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return logsCursor{}, fmt.Errorf("%w: invalid cursor %q", ErrInvalidValue, s)
+	}
+
+	seen, err := strconv.Atoi(parts[1])
+	if err != nil || seen < 1 {
+		return logsCursor{}, fmt.Errorf("%w: invalid cursor %q", ErrInvalidValue, s)
+	}
+
+	if cockpit.Direction(parts[2]) != cursor.direction {
+		return logsCursor{}, fmt.Errorf(
+			"%w: the cursor was returned for the %q direction, not %q", ErrInvalidValue, parts[2], cursor.direction,
 		)
 	}
 
-	// The resource name used in Cockpit Logs is the function's subdomain (the part before the first dot).
-	resourceName, _, _ := strings.Cut(function.DomainName, ".")
+	if parts[3] != cursor.filters {
+		return logsCursor{}, fmt.Errorf("%w: the cursor was returned for other filters", ErrInvalidValue)
+	}
+
+	cursor.timestamp, cursor.seen = time.Unix(0, nanos).UTC(), seen
+
+	return cursor, nil
+}
+
+// hashLogsFilters returns a short hash of the filters of the query.
+func hashLogsFilters(query cockpit.LogQuery) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{query.Contains, query.Regexp, query.Level}, "\x00")))
+
+	return hex.EncodeToString(h[:8])
+}
 
-	logs, err := t.cockpitClient.ListFunctionLogs(ctx, resourceName, req.StartTime, req.EndTime)
+func (c logsCursor) String() string {
+	return strings.Join([]string{
+		strconv.FormatInt(c.timestamp.UnixNano(), 10),
+		strconv.Itoa(c.seen),
+		string(c.direction),
+		c.filters,
+	}, "-")
+}
+
+// apply narrows the window of the query to the logs after the cursor. The logs with the same
+// timestamp as the cursor are still queried, as they may not have all been returned.
+func (c logsCursor) apply(query cockpit.LogQuery) cockpit.LogQuery {
+	if c.seen == 0 {
+		return query
+	}
+
+	if query.Direction == cockpit.DirectionBackward {
+		// Loki excludes the end of the window.
+		query.End = c.timestamp.Add(time.Nanosecond)
+	} else {
+		query.Start = c.timestamp
+	}
+
+	query.Limit += c.seen
+
+	return query
+}
+
+// advance returns the cursor after the logs, which were returned after this cursor.
+func (c logsCursor) advance(logs []cockpit.Log) logsCursor {
+	if len(logs) == 0 {
+		return c
+	}
+
+	next := newLogsCursor(logs)
+	next.direction, next.filters = c.direction, c.filters
+
+	if next.timestamp.Equal(c.timestamp) {
+		next.seen += c.seen
+	}
+
+	return next
+}
+
+// skipSeen removes the logs returned by the previous page.
+func (c logsCursor) skipSeen(logs []cockpit.Log) []cockpit.Log {
+	skipped := 0
+
+	return slices.DeleteFunc(logs, func(log cockpit.Log) bool {
+		if skipped < c.seen && log.Timestamp.Equal(c.timestamp) {
+			skipped++
+
+			return true
+		}
+
+		return false
+	})
+}
+
+// getFunctionCockpit looks up a function by name and returns the Cockpit client of its project
+// and region, along with the resource name under which its logs and metrics are stored.
+func (t *Tools) getFunctionCockpit(
+	ctx context.Context,
+	scope Scope,
+	functionName string,
+) (cockpit.Client, string, error) {
+	fun, ns, err := getFunctionAndNamespaceByFunctionName(ctx, t.functionsAPI, scope, functionName)
 	if err != nil {
-		return nil, FetchFunctionLogsResponse{}, fmt.Errorf("listing function logs: %w", err)
+		return nil, "", fmt.Errorf("getting function by name: %w", err)
 	}
 
-	return nil, FetchFunctionLogsResponse{Logs: logs}, nil
+	return t.getCockpitClient(ns.ProjectID, ns.Region), cockpitResourceName(fun), nil
+}
+
+// cockpitResourceName returns the resource name used in Cockpit Logs, which is
+// the function's subdomain (the part before the first dot).
+func cockpitResourceName(fun *function.Function) string {
+	resourceName, _, _ := strings.Cut(fun.DomainName, ".")
+
+	return resourceName
 }
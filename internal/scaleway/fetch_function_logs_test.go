@@ -1,14 +1,17 @@
 package scaleway
 
 import (
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockcockpit"
 	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -17,14 +20,18 @@ import (
 func TestTools_FetchFunctionLogs(t *testing.T) {
 	t.Parallel()
 
+	timeoutErrors := cockpit.LogQuery{Direction: cockpit.DirectionBackward, Contains: "timeout", Level: "error"}
+	allLogs := cockpit.LogQuery{Direction: cockpit.DirectionBackward}
+
 	tt := []struct {
-		name            string
-		givenFunction   *function.Function
-		onNamespace     *function.Namespace
-		req             FetchFunctionLogsRequest
-		wantLogsFetched bool
-		wantResp        FetchFunctionLogsResponse
-		wantError       require.ErrorAssertionFunc
+		name          string
+		givenFunction *function.Function
+		onNamespace   *function.Namespace
+		req           FetchFunctionLogsRequest
+		givenLogs     []cockpit.Log
+		wantQuery     *cockpit.LogQuery
+		wantResp      FetchFunctionLogsResponse
+		wantError     require.ErrorAssertionFunc
 	}{
 		{
 			name:          "function not found",
@@ -37,9 +44,8 @@ func TestTools_FetchFunctionLogs(t *testing.T) {
 			},
 		},
 		{
-			// This is a current limitation: we cannot fetch logs if the function's
-			// namespace does not belong to the same project as the active Scaleway profile.
-			name: "namespace projectID does not match profile projectID",
+			// The logs are read from the Cockpit of the project of the namespace.
+			name: "function in another project",
 			givenFunction: &function.Function{
 				ID:          fixed.SomeFunctionID,
 				Name:        fixed.SomeFunctionName,
@@ -58,9 +64,21 @@ func TestTools_FetchFunctionLogs(t *testing.T) {
 				StartTime:    fixed.SomeTimestampA,
 				EndTime:      fixed.SomeTimestampB,
 			},
-			wantError: func(t require.TestingT, err error, _ ...any) {
-				assert.ErrorIs(t, err, ErrMultipleProjectsNotSupported)
+			givenLogs: []cockpit.Log{
+				{Timestamp: fixed.SomeTimestampA, Message: "Function started"},
+			},
+			wantQuery: &cockpit.LogQuery{
+				Start:     fixed.SomeTimestampA,
+				End:       fixed.SomeTimestampB,
+				Limit:     defaultLogsLimit,
+				Direction: cockpit.DirectionBackward,
+			},
+			wantResp: FetchFunctionLogsResponse{
+				Logs: []cockpit.Log{
+					{Timestamp: fixed.SomeTimestampA, Message: "Function started"},
+				},
 			},
+			wantError: require.NoError,
 		},
 		{
 			name: "success",
@@ -81,15 +99,114 @@ func TestTools_FetchFunctionLogs(t *testing.T) {
 				StartTime:    fixed.SomeTimestampA,
 				EndTime:      fixed.SomeTimestampB,
 			},
-			wantLogsFetched: true,
+			givenLogs: []cockpit.Log{
+				{Timestamp: fixed.SomeTimestampB, Message: "Handling request"},
+				{Timestamp: fixed.SomeTimestampA, Message: "Function started"},
+			},
+			wantQuery: &cockpit.LogQuery{
+				Start:     fixed.SomeTimestampA,
+				End:       fixed.SomeTimestampB,
+				Limit:     defaultLogsLimit,
+				Direction: cockpit.DirectionBackward,
+			},
 			wantResp: FetchFunctionLogsResponse{
 				Logs: []cockpit.Log{
-					{Timestamp: fixed.SomeTimestampA, Message: "Function started"},
 					{Timestamp: fixed.SomeTimestampB, Message: "Handling request"},
+					{Timestamp: fixed.SomeTimestampA, Message: "Function started"},
 				},
 			},
 			wantError: require.NoError,
 		},
+		{
+			name: "next page with filters",
+			givenFunction: &function.Function{
+				ID:          fixed.SomeFunctionID,
+				Name:        fixed.SomeFunctionName,
+				NamespaceID: fixed.SomeNamespaceID,
+			},
+			onNamespace: &function.Namespace{
+				ID:        fixed.SomeNamespaceID,
+				Name:      fixed.SomeNamespaceName,
+				Status:    function.NamespaceStatusReady,
+				ProjectID: fixed.SomeProjectID,
+				Region:    fixed.SomeRegion,
+			},
+			req: FetchFunctionLogsRequest{
+				FunctionName: fixed.SomeFunctionName,
+				StartTime:    fixed.SomeTimestampA,
+				EndTime:      fixed.SomeTimestampB,
+				Level:        "ERROR",
+				Contains:     "timeout",
+				Limit:        2,
+				// The previous page ended with one log at SomeTimestampB.
+				Cursor: someLogsCursor(timeoutErrors, fixed.SomeTimestampB, 1),
+			},
+			givenLogs: []cockpit.Log{
+				{Timestamp: fixed.SomeTimestampB, Message: "timeout (previous page)"},
+				{Timestamp: fixed.SomeTimestampB, Message: "timeout 1"},
+				{Timestamp: fixed.SomeTimestampA.Add(time.Second), Message: "timeout 2"},
+			},
+			wantQuery: &cockpit.LogQuery{
+				Start:     fixed.SomeTimestampA,
+				End:       fixed.SomeTimestampB.Add(time.Nanosecond),
+				Limit:     3,
+				Direction: cockpit.DirectionBackward,
+				Contains:  "timeout",
+				Level:     "error",
+			},
+			wantResp: FetchFunctionLogsResponse{
+				Logs: []cockpit.Log{
+					{Timestamp: fixed.SomeTimestampB, Message: "timeout 1"},
+					{Timestamp: fixed.SomeTimestampA.Add(time.Second), Message: "timeout 2"},
+				},
+				NextCursor: someLogsCursor(timeoutErrors, fixed.SomeTimestampA.Add(time.Second), 1),
+			},
+			wantError: require.NoError,
+		},
+		{
+			// More logs than the limit share the same timestamp: the cursor must move past them.
+			name: "next page at the same timestamp",
+			givenFunction: &function.Function{
+				ID:          fixed.SomeFunctionID,
+				Name:        fixed.SomeFunctionName,
+				NamespaceID: fixed.SomeNamespaceID,
+			},
+			onNamespace: &function.Namespace{
+				ID:        fixed.SomeNamespaceID,
+				Name:      fixed.SomeNamespaceName,
+				Status:    function.NamespaceStatusReady,
+				ProjectID: fixed.SomeProjectID,
+				Region:    fixed.SomeRegion,
+			},
+			req: FetchFunctionLogsRequest{
+				FunctionName: fixed.SomeFunctionName,
+				StartTime:    fixed.SomeTimestampA,
+				EndTime:      fixed.SomeTimestampB,
+				Limit:        2,
+				// The previous page ended with two logs at SomeTimestampB.
+				Cursor: someLogsCursor(allLogs, fixed.SomeTimestampB, 2),
+			},
+			givenLogs: []cockpit.Log{
+				{Timestamp: fixed.SomeTimestampB, Message: "log 1 (previous page)"},
+				{Timestamp: fixed.SomeTimestampB, Message: "log 2 (previous page)"},
+				{Timestamp: fixed.SomeTimestampB, Message: "log 3"},
+				{Timestamp: fixed.SomeTimestampB, Message: "log 4"},
+			},
+			wantQuery: &cockpit.LogQuery{
+				Start:     fixed.SomeTimestampA,
+				End:       fixed.SomeTimestampB.Add(time.Nanosecond),
+				Limit:     4,
+				Direction: cockpit.DirectionBackward,
+			},
+			wantResp: FetchFunctionLogsResponse{
+				Logs: []cockpit.Log{
+					{Timestamp: fixed.SomeTimestampB, Message: "log 3"},
+					{Timestamp: fixed.SomeTimestampB, Message: "log 4"},
+				},
+				NextCursor: someLogsCursor(allLogs, fixed.SomeTimestampB, 4),
+			},
+			wantError: require.NoError,
+		},
 	}
 
 	for _, tc := range tt {
@@ -122,24 +239,23 @@ func TestTools_FetchFunctionLogs(t *testing.T) {
 				).Once()
 			}
 
-			if tc.wantLogsFetched {
+			if tc.wantQuery != nil {
 				resourceName, _, _ := strings.Cut(tc.givenFunction.DomainName, ".")
 
 				mockCockpitClient.EXPECT().
-					ListFunctionLogs(
-						mock.Anything,
-						resourceName,
-						tc.req.StartTime,
-						tc.req.EndTime,
-					).
-					Return(tc.wantResp.Logs, nil).
+					ListFunctionLogs(mock.Anything, resourceName, *tc.wantQuery).
+					Return(tc.givenLogs, nil).
 					Once()
 			}
 
 			tools := &Tools{
-				functionsAPI:  mockFunctionsAPI,
-				cockpitClient: mockCockpitClient,
-				projectID:     fixed.SomeProjectID,
+				functionsAPI: mockFunctionsAPI,
+				newCockpitClient: func(projectID string, region scw.Region) cockpit.Client {
+					assert.Equal(t, tc.onNamespace.ProjectID, projectID)
+					assert.Equal(t, tc.onNamespace.Region, region)
+
+					return mockCockpitClient
+				},
 			}
 
 			_, resp, err := tools.FetchFunctionLogs(t.Context(), nil, tc.req)
@@ -154,3 +270,108 @@ func TestTools_FetchFunctionLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchFunctionLogsRequest_toLogQuery(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		req       FetchFunctionLogsRequest
+		wantError require.ErrorAssertionFunc
+	}{
+		{
+			name:      "defaults",
+			req:       FetchFunctionLogsRequest{},
+			wantError: require.NoError,
+		},
+		{
+			name: "limit too high",
+			req:  FetchFunctionLogsRequest{Limit: maxLogsLimit + 1},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "unknown direction",
+			req:  FetchFunctionLogsRequest{Direction: "sideways"},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "unknown level",
+			req:  FetchFunctionLogsRequest{Level: "loud"},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "invalid regexp",
+			req:  FetchFunctionLogsRequest{Regexp: "(unclosed"},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tc.req.toLogQuery()
+			tc.wantError(t, err)
+		})
+	}
+}
+
+func TestParseLogsCursor(t *testing.T) {
+	t.Parallel()
+
+	query := cockpit.LogQuery{Direction: cockpit.DirectionBackward, Contains: "timeout"}
+	cursor := someLogsCursor(query, fixed.SomeTimestampA, 2)
+
+	got, err := parseLogsCursor(cursor, query)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, got.String())
+
+	tt := []struct {
+		name   string
+		cursor string
+		query  cockpit.LogQuery
+	}{
+		{
+			name:   "malformed",
+			cursor: strconv.FormatInt(fixed.SomeTimestampA.UnixNano(), 10) + "-2",
+			query:  query,
+		},
+		{
+			name:   "other direction",
+			cursor: cursor,
+			query:  cockpit.LogQuery{Direction: cockpit.DirectionForward, Contains: "timeout"},
+		},
+		{
+			name:   "other filters",
+			cursor: cursor,
+			query:  cockpit.LogQuery{Direction: cockpit.DirectionBackward, Contains: "error"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseLogsCursor(tc.cursor, tc.query)
+			require.ErrorIs(t, err, ErrInvalidValue)
+		})
+	}
+}
+
+// someLogsCursor returns the cursor of a page of the query, which ended with seen logs at the timestamp.
+func someLogsCursor(query cockpit.LogQuery, timestamp time.Time, seen int) string {
+	return logsCursor{
+		timestamp: timestamp,
+		seen:      seen,
+		direction: query.Direction,
+		filters:   hashLogsFilters(query),
+	}.String()
+}
@@ -0,0 +1,196 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// minMetricsStep matches the rate at which Cockpit scrapes the function metrics.
+	minMetricsStep = time.Minute
+	// defaultMetricsPoints is the number of points per series when no step is given.
+	defaultMetricsPoints = 60
+	maxMetricsPoints     = 1000
+)
+
+//nolint:gochecknoglobals
+var fetchFunctionMetricsTool = &mcp.Tool{
+	Name: "fetch_function_metrics",
+	Description: `Fetch the metrics of a Scaleway Function over a time range, for instance to tune
+	its "memory_limit" or "min_scale".
+
+	- "metrics" is a list of presets: "invocations", "errors" (4xx and 5xx responses),
+	  "duration" (p50, p95 and p99 in seconds), "cold_starts", "memory" (max usage and limit in bytes)
+	  and "instances". Defaults to all of them.
+	- "step" is the duration between two points (e.g. "5m"), at least "1m". By default, there are 60 points.
+	  Counters such as invocations are summed over each step.
+	- "notes" lists the metrics without any data.`,
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
+}
+
+type FetchFunctionMetricsRequest struct {
+	Scope
+
+	FunctionName string    `json:"function_name"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Metrics      []string  `json:"metrics,omitempty"`
+	Step         string    `json:"step,omitempty"`
+}
+
+type FetchFunctionMetricsResponse struct {
+	Step   string                 `json:"step"`
+	Series []FunctionMetricSeries `json:"series"`
+	// Notes explain the metrics without any data.
+	Notes []string `json:"notes,omitempty"`
+}
+
+type FunctionMetricSeries struct {
+	cockpit.MetricSeries
+
+	// Summary is not set when there is no data, e.g. when the function was not called.
+	Summary *MetricSummary `json:"summary,omitempty"`
+}
+
+type MetricSummary struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Avg  float64 `json:"avg"`
+	Last float64 `json:"last"`
+}
+
+func (t *Tools) FetchFunctionMetrics(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	req FetchFunctionMetricsRequest,
+) (*mcp.CallToolResult, FetchFunctionMetricsResponse, error) {
+	metrics, step, err := req.validate()
+	if err != nil {
+		return nil, FetchFunctionMetricsResponse{}, err
+	}
+
+	cockpitClient, resourceName, err := t.getFunctionCockpit(ctx, req.Scope, req.FunctionName)
+	if err != nil {
+		return nil, FetchFunctionMetricsResponse{}, err
+	}
+
+	resp := FetchFunctionMetricsResponse{
+		Step:   step.String(),
+		Series: []FunctionMetricSeries{},
+	}
+
+	for _, metric := range metrics {
+		series, err := cockpitClient.ListFunctionMetrics(ctx, resourceName, cockpit.MetricQuery{
+			Metric: metric,
+			Start:  req.StartTime,
+			End:    req.EndTime,
+			Step:   step,
+		})
+		if err != nil {
+			return nil, FetchFunctionMetricsResponse{}, fmt.Errorf("listing function metrics: %w", err)
+		}
+
+		hasData := false
+
+		for _, s := range series {
+			resp.Series = append(resp.Series, FunctionMetricSeries{
+				MetricSeries: s,
+				Summary:      summarizePoints(s.Points),
+			})
+
+			hasData = hasData || len(s.Points) > 0
+		}
+
+		if !hasData {
+			resp.Notes = append(resp.Notes, fmt.Sprintf(
+				"no data for metric %q: either the function was not called over the time range, "+
+					"or Cockpit does not collect this metric for the function",
+				metric,
+			))
+		}
+	}
+
+	return nil, resp, nil
+}
+
+// validate returns the metrics and the step to query.
+func (req FetchFunctionMetricsRequest) validate() ([]cockpit.Metric, time.Duration, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, 0, fmt.Errorf("%w: end_time must be after start_time", ErrInvalidValue)
+	}
+
+	metrics := cockpit.Metrics()
+
+	if len(req.Metrics) > 0 {
+		metrics = make([]cockpit.Metric, 0, len(req.Metrics))
+
+		for _, m := range req.Metrics {
+			if !slices.Contains(cockpit.Metrics(), cockpit.Metric(m)) {
+				return nil, 0, fmt.Errorf(
+					"%w: unknown metric %q, must be one of %v",
+					ErrInvalidValue, m, cockpit.Metrics(),
+				)
+			}
+
+			metrics = append(metrics, cockpit.Metric(m))
+		}
+	}
+
+	timeRange := req.EndTime.Sub(req.StartTime)
+
+	// Rounded up to the minute, so that the points are aligned on the scrapes.
+	step := max(minMetricsStep, (timeRange/defaultMetricsPoints + time.Minute - 1).Truncate(time.Minute))
+
+	if req.Step != "" {
+		var err error
+
+		step, err = time.ParseDuration(req.Step)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parsing step: %w", err)
+		}
+
+		if step < minMetricsStep {
+			return nil, 0, fmt.Errorf("%w: step must be at least %s", ErrInvalidValue, minMetricsStep)
+		}
+	}
+
+	if timeRange/step > maxMetricsPoints {
+		return nil, 0, fmt.Errorf(
+			"%w: step is too small for the time range, there can be at most %d points",
+			ErrInvalidValue, maxMetricsPoints,
+		)
+	}
+
+	return metrics, step, nil
+}
+
+func summarizePoints(points []cockpit.Point) *MetricSummary {
+	if len(points) == 0 {
+		return nil
+	}
+
+	summary := &MetricSummary{
+		Min:  points[0].Value,
+		Max:  points[0].Value,
+		Last: points[len(points)-1].Value,
+	}
+
+	var sum float64
+
+	for _, p := range points {
+		summary.Min = min(summary.Min, p.Value)
+		summary.Max = max(summary.Max, p.Value)
+		sum += p.Value
+	}
+
+	summary.Avg = sum / float64(len(points))
+
+	return summary
+}
@@ -0,0 +1,180 @@
+package scaleway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockcockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_FetchFunctionMetrics(t *testing.T) {
+	t.Parallel()
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+	mockCockpitClient := mockcockpit.NewMockClient(t)
+
+	mockFunctionsAPI.EXPECT().ListFunctions(mock.Anything, mock.Anything).Return(
+		&function.ListFunctionsResponse{
+			Functions: []*function.Function{{
+				ID:          fixed.SomeFunctionID,
+				Name:        fixed.SomeFunctionName,
+				NamespaceID: fixed.SomeNamespaceID,
+				DomainName:  "my-function-xyz.functions.fr-par.scw.cloud",
+			}},
+		},
+		nil,
+	).Once()
+
+	mockFunctionsAPI.EXPECT().GetNamespace(&function.GetNamespaceRequest{
+		NamespaceID: fixed.SomeNamespaceID,
+	}, mock.Anything).Return(&function.Namespace{
+		ID:        fixed.SomeNamespaceID,
+		ProjectID: fixed.SomeProjectID,
+		Region:    fixed.SomeRegion,
+	}, nil).Once()
+
+	start := fixed.SomeTimestampA
+	end := start.Add(time.Hour)
+
+	mockCockpitClient.EXPECT().ListFunctionMetrics(mock.Anything, "my-function-xyz", cockpit.MetricQuery{
+		Metric: cockpit.MetricInvocations,
+		Start:  start,
+		End:    end,
+		Step:   time.Minute,
+	}).Return([]cockpit.MetricSeries{{
+		Metric: cockpit.MetricInvocations,
+		Name:   "invocations",
+		Unit:   "requests",
+		Points: []cockpit.Point{
+			{Timestamp: start, Value: 4},
+			{Timestamp: start.Add(time.Minute), Value: 2},
+		},
+	}}, nil).Once()
+
+	mockCockpitClient.EXPECT().ListFunctionMetrics(mock.Anything, "my-function-xyz", cockpit.MetricQuery{
+		Metric: cockpit.MetricColdStarts,
+		Start:  start,
+		End:    end,
+		Step:   time.Minute,
+	}).Return([]cockpit.MetricSeries{{
+		Metric: cockpit.MetricColdStarts,
+		Name:   "cold_starts",
+		Unit:   "cold starts",
+		Points: []cockpit.Point{},
+	}}, nil).Once()
+
+	tools := &Tools{
+		functionsAPI:     mockFunctionsAPI,
+		newCockpitClient: func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+	}
+
+	_, got, err := tools.FetchFunctionMetrics(t.Context(), nil, FetchFunctionMetricsRequest{
+		FunctionName: fixed.SomeFunctionName,
+		StartTime:    start,
+		EndTime:      end,
+		Metrics:      []string{"invocations", "cold_starts"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "1m0s", got.Step)
+	require.Len(t, got.Series, 2)
+	assert.Equal(t, &MetricSummary{Min: 2, Max: 4, Avg: 3, Last: 2}, got.Series[0].Summary)
+	assert.Nil(t, got.Series[1].Summary)
+
+	require.Len(t, got.Notes, 1)
+	assert.Contains(t, got.Notes[0], `no data for metric "cold_starts"`)
+}
+
+func TestFetchFunctionMetricsRequest_validate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		req       FetchFunctionMetricsRequest
+		wantStep  time.Duration
+		wantError require.ErrorAssertionFunc
+	}{
+		{
+			name: "default step",
+			req: FetchFunctionMetricsRequest{
+				StartTime: fixed.SomeTimestampA,
+				EndTime:   fixed.SomeTimestampA.Add(24 * time.Hour),
+			},
+			wantStep:  24 * time.Minute,
+			wantError: require.NoError,
+		},
+		{
+			name: "default step is rounded up to the minute",
+			req: FetchFunctionMetricsRequest{
+				StartTime: fixed.SomeTimestampA,
+				EndTime:   fixed.SomeTimestampA.Add(90 * time.Minute),
+			},
+			wantStep:  2 * time.Minute,
+			wantError: require.NoError,
+		},
+		{
+			name: "step too small",
+			req: FetchFunctionMetricsRequest{
+				StartTime: fixed.SomeTimestampA,
+				EndTime:   fixed.SomeTimestampA.Add(time.Hour),
+				Step:      "30s",
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "too many points",
+			req: FetchFunctionMetricsRequest{
+				StartTime: fixed.SomeTimestampA,
+				EndTime:   fixed.SomeTimestampA.Add(30 * 24 * time.Hour),
+				Step:      "1m",
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "unknown metric",
+			req: FetchFunctionMetricsRequest{
+				StartTime: fixed.SomeTimestampA,
+				EndTime:   fixed.SomeTimestampA.Add(time.Hour),
+				Metrics:   []string{"cpu"},
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "end before start",
+			req: FetchFunctionMetricsRequest{
+				StartTime: fixed.SomeTimestampB,
+				EndTime:   fixed.SomeTimestampA,
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, step, err := tc.req.validate()
+			tc.wantError(t, err)
+
+			if err == nil {
+				assert.Equal(t, tc.wantStep, step)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+)
+
+var ErrDeploymentHistoryUnavailable = errors.New("deployment history is unavailable")
+
+//nolint:gochecknoglobals
+var (
+	listFunctionDeploymentsTool = &mcp.Tool{
+		Name: "list_function_deployments",
+		Description: `List the previous deployments of a Scaleway Function, newest first.
+		Only deployments made with this tool on this machine are recorded.`,
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+	rollbackFunctionTool = &mcp.Tool{
+		Name: "rollback_function",
+		Description: `Redeploy the code and configuration of a previous deployment of a Scaleway Function.
+		It can only be used on functions created by this tool.
+
+		- Without "deployment_id", the function is rolled back to the last ready deployment before the current one.
+		- Secrets are not part of the history: the current secrets are kept.`,
+	}
+)
+
+type ListFunctionDeploymentsRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+}
+
+type ListFunctionDeploymentsResponse struct {
+	Deployments []DeploymentRecord `json:"deployments"`
+}
+
+func (t *Tools) ListFunctionDeployments(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in ListFunctionDeploymentsRequest,
+) (*mcp.CallToolResult, ListFunctionDeploymentsResponse, error) {
+	if t.deploymentHistory == nil {
+		return nil, ListFunctionDeploymentsResponse{}, ErrDeploymentHistoryUnavailable
+	}
+
+	fun, err := getFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, ListFunctionDeploymentsResponse{}, fmt.Errorf("getting function by name: %w", err)
+	}
+
+	records, err := t.deploymentHistory.List(fun.ID)
+	if err != nil {
+		return nil, ListFunctionDeploymentsResponse{}, fmt.Errorf("listing deployments: %w", err)
+	}
+
+	return nil, ListFunctionDeploymentsResponse{Deployments: records}, nil
+}
+
+type RollbackFunctionRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+func (t *Tools) RollbackFunction(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	in RollbackFunctionRequest,
+) (*mcp.CallToolResult, FunctionDeployment, error) {
+	if t.deploymentHistory == nil {
+		return nil, FunctionDeployment{}, ErrDeploymentHistoryUnavailable
+	}
+
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, FunctionDeployment{}, err
+	}
+
+	records, err := t.deploymentHistory.List(fun.ID)
+	if err != nil {
+		return nil, FunctionDeployment{}, fmt.Errorf("listing deployments: %w", err)
+	}
+
+	target, err := selectRollbackTarget(records, in.DeploymentID)
+	if err != nil {
+		return nil, FunctionDeployment{}, err
+	}
+
+	archive, err := t.deploymentHistory.CodeArchive(target)
+	if err != nil {
+		return nil, FunctionDeployment{}, err
+	}
+
+	deployment, err := t.updateFunctionWithArchive(
+		ctx,
+		req,
+		NewFunctionDeploymentProgress(in.FunctionName),
+		fun,
+		archive,
+		target.Config.ToUpdateRequest("", in.FunctionName),
+	)
+	if err != nil {
+		return nil, FunctionDeployment{}, err
+	}
+
+	return nil, deployment, nil
+}
+
+// selectRollbackTarget returns the deployment with the given ID or, by default,
+// the newest ready deployment before the current one.
+func selectRollbackTarget(records []DeploymentRecord, deploymentID string) (DeploymentRecord, error) {
+	if deploymentID != "" {
+		for _, r := range records {
+			if r.ID == deploymentID {
+				return r, nil
+			}
+		}
+
+		return DeploymentRecord{}, fmt.Errorf("%w: deployment %q", ErrResourceNotFound, deploymentID)
+	}
+
+	// The first record is the current deployment.
+	for _, r := range records[min(1, len(records)):] {
+		if r.Status == function.FunctionStatusReady.String() {
+			return r, nil
+		}
+	}
+
+	return DeploymentRecord{}, fmt.Errorf("%w: no previous ready deployment", ErrResourceNotFound)
+}
+
+// recordDeployment adds the deployment to the history. Failing to do so does not fail the deployment.
+func (t *Tools) recordDeployment(ctx context.Context, fun *function.Function, archive *CodeArchive) {
+	if t.deploymentHistory == nil {
+		return
+	}
+
+	if _, err := t.deploymentHistory.Record(fun, archive); err != nil {
+		slogctx.FromContext(ctx).WarnContext(ctx, "failed to record deployment",
+			"function_name", fun.Name,
+			"error", err,
+		)
+	}
+}
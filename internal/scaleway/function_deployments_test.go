@@ -0,0 +1,88 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_RollbackFunction(t *testing.T) {
+	t.Parallel()
+
+	history := NewDeploymentHistory(t.TempDir())
+	archive := newTestCodeArchive(t, "print('hello')")
+
+	previous, err := history.Record(&function.Function{
+		ID:      fixed.SomeFunctionID,
+		Name:    fixed.SomeFunctionName,
+		Status:  function.FunctionStatusReady,
+		Runtime: function.FunctionRuntimePython313,
+		Handler: "handler.handle",
+	}, archive)
+	require.NoError(t, err)
+
+	current := &function.Function{
+		ID:      fixed.SomeFunctionID,
+		Name:    fixed.SomeFunctionName,
+		Status:  function.FunctionStatusReady,
+		Runtime: function.FunctionRuntimePython313,
+		Handler: "handler.broken",
+		Tags: []string{
+			constants.TagCreatedByScalewayMCP,
+			constants.TagCodeArchiveDigestPrefix + archive.Digest,
+		},
+	}
+
+	_, err = history.Record(current, archive)
+	require.NoError(t, err)
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+	mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+		Name: scw.StringPtr(fixed.SomeFunctionName),
+	}, mock.Anything).Return(&function.ListFunctionsResponse{
+		Functions: []*function.Function{current},
+	}, nil).Once()
+
+	// The code archive did not change, so only the configuration is redeployed.
+	mockFunctionsAPI.EXPECT().UpdateFunction(mock.MatchedBy(func(req *function.UpdateFunctionRequest) bool {
+		return req.FunctionID == fixed.SomeFunctionID && *req.Handler == "handler.handle"
+	}), mock.Anything).Return(current, nil).Once()
+
+	mockFunctionsAPI.EXPECT().GetFunction(&function.GetFunctionRequest{
+		FunctionID: fixed.SomeFunctionID,
+	}, mock.Anything).Return(&function.Function{
+		ID:      fixed.SomeFunctionID,
+		Name:    fixed.SomeFunctionName,
+		Status:  function.FunctionStatusReady,
+		Runtime: function.FunctionRuntimePython313,
+		Handler: "handler.handle",
+	}, nil).Once()
+
+	mockFunctionsAPI.EXPECT().ListDomains(&function.ListDomainsRequest{
+		FunctionID: fixed.SomeFunctionID,
+	}, mock.Anything, mock.Anything).Return(&function.ListDomainsResponse{
+		Domains: []*function.Domain{{Hostname: "webhooks.example.com"}},
+	}, nil).Once()
+
+	tools := &Tools{functionsAPI: mockFunctionsAPI, deploymentHistory: history}
+
+	_, got, err := tools.RollbackFunction(t.Context(), nil, RollbackFunctionRequest{
+		FunctionName: fixed.SomeFunctionName,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ready", got.Status)
+	assert.Equal(t, []string{"webhooks.example.com"}, got.Hostnames)
+
+	records, err := history.List(fixed.SomeFunctionID)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, previous.Config, records[0].Config)
+}
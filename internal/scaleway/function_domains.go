@@ -0,0 +1,207 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+//nolint:gochecknoglobals
+var (
+	attachFunctionDomainTool = &mcp.Tool{
+		Name: "attach_function_domain",
+		Description: `Attach a custom domain to a Scaleway Function, and wait until it is ready.
+		It can only be used on functions created by this tool.
+
+		Before attaching the domain, a CNAME record must point from the custom domain to the default hostname
+		of the function (see "cname_target" in the result). If the domain ends up in error,
+		create the CNAME record, detach the domain and attach it again.`,
+	}
+	listFunctionDomainsTool = &mcp.Tool{
+		Name:        "list_function_domains",
+		Description: "List the custom domains of a Scaleway Function.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+	detachFunctionDomainTool = &mcp.Tool{
+		Name:        "detach_function_domain",
+		Description: "Detach a custom domain from a Scaleway Function. It can only be used on functions created by this tool.",
+	}
+)
+
+type FunctionDomain struct {
+	ID           string `json:"id"`
+	Hostname     string `json:"hostname"`
+	FunctionID   string `json:"function_id"`
+	URL          string `json:"url"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	// CNAMETarget is the hostname that the custom domain must point to.
+	CNAMETarget string `json:"cname_target"`
+}
+
+func NewFunctionDomainFromSDK(d *function.Domain, fun *function.Function) FunctionDomain {
+	return FunctionDomain{
+		ID:           d.ID,
+		Hostname:     d.Hostname,
+		FunctionID:   d.FunctionID,
+		URL:          d.URL,
+		Status:       d.Status.String(),
+		ErrorMessage: valueOrDefault(d.ErrorMessage, ""),
+		CNAMETarget:  fun.DomainName,
+	}
+}
+
+type AttachFunctionDomainRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+	Hostname     string `json:"hostname"`
+}
+
+func (t *Tools) AttachFunctionDomain(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in AttachFunctionDomainRequest,
+) (*mcp.CallToolResult, FunctionDomain, error) {
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, FunctionDomain{}, err
+	}
+
+	domain, err := t.functionsAPI.CreateDomain(&function.CreateDomainRequest{
+		Region:     fun.Region,
+		FunctionID: fun.ID,
+		Hostname:   in.Hostname,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, FunctionDomain{}, fmt.Errorf(
+			"creating domain (is there a CNAME record from %q to %q?): %w",
+			in.Hostname,
+			fun.DomainName,
+			err,
+		)
+	}
+
+	domain, err = t.functionsAPI.WaitForDomain(&function.WaitForDomainRequest{
+		Region:   fun.Region,
+		DomainID: domain.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, FunctionDomain{}, fmt.Errorf("waiting for domain to be ready: %w", err)
+	}
+
+	return nil, NewFunctionDomainFromSDK(domain, fun), nil
+}
+
+type ListFunctionDomainsRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+}
+
+type ListFunctionDomainsResponse struct {
+	Domains []FunctionDomain `json:"domains"`
+}
+
+func (t *Tools) ListFunctionDomains(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in ListFunctionDomainsRequest,
+) (*mcp.CallToolResult, ListFunctionDomainsResponse, error) {
+	fun, err := getFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, ListFunctionDomainsResponse{}, fmt.Errorf("getting function by name: %w", err)
+	}
+
+	domains, err := listDomains(ctx, t.functionsAPI, fun)
+	if err != nil {
+		return nil, ListFunctionDomainsResponse{}, err
+	}
+
+	resp := ListFunctionDomainsResponse{
+		Domains: make([]FunctionDomain, 0, len(domains)),
+	}
+
+	for _, d := range domains {
+		resp.Domains = append(resp.Domains, NewFunctionDomainFromSDK(d, fun))
+	}
+
+	return nil, resp, nil
+}
+
+type DetachFunctionDomainRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+	Hostname     string `json:"hostname"`
+}
+
+func (t *Tools) DetachFunctionDomain(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in DetachFunctionDomainRequest,
+) (*mcp.CallToolResult, FunctionDomain, error) {
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, FunctionDomain{}, err
+	}
+
+	domains, err := listDomains(ctx, t.functionsAPI, fun)
+	if err != nil {
+		return nil, FunctionDomain{}, err
+	}
+
+	for _, d := range domains {
+		if d.Hostname != in.Hostname {
+			continue
+		}
+
+		domain, err := t.functionsAPI.DeleteDomain(&function.DeleteDomainRequest{
+			Region:   fun.Region,
+			DomainID: d.ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, FunctionDomain{}, fmt.Errorf("deleting domain: %w", err)
+		}
+
+		return nil, NewFunctionDomainFromSDK(domain, fun), nil
+	}
+
+	return nil, FunctionDomain{}, fmt.Errorf("%w: domain %q", ErrResourceNotFound, in.Hostname)
+}
+
+func listDomains(ctx context.Context, functionAPI FunctionAPI, fun *function.Function) ([]*function.Domain, error) {
+	resp, err := functionAPI.ListDomains(&function.ListDomainsRequest{
+		Region:     fun.Region,
+		FunctionID: fun.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("listing domains: %w", err)
+	}
+
+	return resp.Domains, nil
+}
+
+// newFunctionWithHostnames converts the function, along with its custom domains. They are only
+// informative: when they can't be listed, the function is returned with its default hostname.
+func (t *Tools) newFunctionWithHostnames(ctx context.Context, fun *function.Function) Function {
+	f := NewFunctionFromSDK(fun)
+
+	domains, err := listDomains(ctx, t.functionsAPI, fun)
+	if err != nil {
+		slogctx.FromContext(ctx).WarnContext(ctx, "Could not list the custom domains of the function",
+			"function_name", fun.Name, "error", err)
+
+		return f
+	}
+
+	f.addCustomDomains(domains)
+
+	return f
+}
@@ -0,0 +1,111 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	someDomainID       = "some-domain-id"
+	someCustomHostname = "webhooks.example.com"
+	someFunctionHost   = "my-function-xyz.functions.fr-par.scw.cloud"
+)
+
+func TestTools_AttachFunctionDomain(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		givenFunction *function.Function
+		shouldCreate  bool
+		want          FunctionDomain
+		wantError     require.ErrorAssertionFunc
+	}{
+		{
+			name: "disallow attaching domain to function not owned by tool",
+			givenFunction: &function.Function{
+				ID:         fixed.SomeFunctionID,
+				Name:       fixed.SomeFunctionName,
+				DomainName: someFunctionHost,
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrResourceNotOwnedByTool)
+			},
+		},
+		{
+			name: "success",
+			givenFunction: &function.Function{
+				ID:         fixed.SomeFunctionID,
+				Name:       fixed.SomeFunctionName,
+				DomainName: someFunctionHost,
+				Tags:       []string{constants.TagCreatedByScalewayMCP},
+			},
+			shouldCreate: true,
+			want: FunctionDomain{
+				ID:          someDomainID,
+				Hostname:    someCustomHostname,
+				FunctionID:  fixed.SomeFunctionID,
+				URL:         "https://" + someCustomHostname,
+				Status:      "ready",
+				CNAMETarget: someFunctionHost,
+			},
+			wantError: require.NoError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+			mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+				Name: scw.StringPtr(fixed.SomeFunctionName),
+			}, mock.Anything).Return(&function.ListFunctionsResponse{
+				Functions: []*function.Function{tc.givenFunction},
+			}, nil).Once()
+
+			if tc.shouldCreate {
+				mockFunctionsAPI.EXPECT().CreateDomain(&function.CreateDomainRequest{
+					FunctionID: fixed.SomeFunctionID,
+					Hostname:   someCustomHostname,
+				}, mock.Anything).Return(&function.Domain{
+					ID:     someDomainID,
+					Status: function.DomainStatusCreating,
+				}, nil).Once()
+
+				mockFunctionsAPI.EXPECT().WaitForDomain(&function.WaitForDomainRequest{
+					DomainID: someDomainID,
+				}, mock.Anything).Return(&function.Domain{
+					ID:         someDomainID,
+					Hostname:   someCustomHostname,
+					FunctionID: fixed.SomeFunctionID,
+					URL:        "https://" + someCustomHostname,
+					Status:     function.DomainStatusReady,
+				}, nil).Once()
+			}
+
+			tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+			_, got, err := tools.AttachFunctionDomain(t.Context(), nil, AttachFunctionDomainRequest{
+				FunctionName: fixed.SomeFunctionName,
+				Hostname:     someCustomHostname,
+			})
+			tc.wantError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+//nolint:gochecknoglobals
+var (
+	createFunctionTokenTool = &mcp.Tool{
+		Name: "create_function_token",
+		Description: `Create a token to call private Scaleway Functions.
+		It can only be used on functions or namespaces created by this tool.
+
+		- Exactly one of "function_name" or "namespace_name" must be set.
+		  A namespace token can call every function of the namespace.
+		- "expires_in" is a duration such as "24h". Without it, the token never expires.
+		- The token is only returned once: it must be sent in the "X-Auth-Token" header.`,
+	}
+	listFunctionTokensTool = &mcp.Tool{
+		Name: "list_function_tokens",
+		Description: `List the tokens of a Scaleway Function or Namespace.
+		Exactly one of "function_name" or "namespace_name" must be set.`,
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+	revokeFunctionTokenTool = &mcp.Tool{
+		Name: "revoke_function_token",
+		Description: `Revoke a token of a Scaleway Function or Namespace.
+		It can only be used on functions or namespaces created by this tool.`,
+	}
+)
+
+type FunctionToken struct {
+	ID          string     `json:"id"`
+	FunctionID  string     `json:"function_id,omitempty"`
+	NamespaceID string     `json:"namespace_id,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	// Token is only set when the token is created.
+	Token string `json:"token,omitempty"`
+}
+
+func NewFunctionTokenFromSDK(t *function.Token) FunctionToken {
+	return FunctionToken{
+		ID:          t.ID,
+		FunctionID:  valueOrDefault(t.FunctionID, ""),
+		NamespaceID: valueOrDefault(t.NamespaceID, ""),
+		Description: valueOrDefault(t.Description, ""),
+		Status:      t.Status.String(),
+		ExpiresAt:   t.ExpiresAt,
+	}
+}
+
+// tokenTarget is the function or the namespace a token applies to, as expected by the token API.
+type tokenTarget struct {
+	functionID  *string
+	namespaceID *string
+	region      scw.Region
+}
+
+// resolveTokenScope returns the function or the namespace a token applies to.
+func resolveTokenScope(
+	ctx context.Context,
+	functionAPI FunctionAPI,
+	scope Scope,
+	functionName, namespaceName string,
+	checkOwnership bool,
+) (tokenTarget, error) {
+	if (functionName == "") == (namespaceName == "") {
+		return tokenTarget{}, fmt.Errorf(
+			"%w: exactly one of \"function_name\" or \"namespace_name\" must be set",
+			ErrInvalidValue,
+		)
+	}
+
+	var (
+		id     string
+		tags   []string
+		region scw.Region
+	)
+
+	if functionName != "" {
+		fun, err := getFunctionByName(ctx, functionAPI, scope, functionName)
+		if err != nil {
+			return tokenTarget{}, fmt.Errorf("getting function by name: %w", err)
+		}
+
+		id, tags, region = fun.ID, fun.Tags, fun.Region
+	} else {
+		ns, err := getFunctionNamespaceByName(ctx, functionAPI, scope, namespaceName)
+		if err != nil {
+			return tokenTarget{}, fmt.Errorf("getting namespace by name: %w", err)
+		}
+
+		id, tags, region = ns.ID, ns.Tags, ns.Region
+	}
+
+	if checkOwnership {
+		if err := checkResourceOwnership(tags); err != nil {
+			return tokenTarget{}, err
+		}
+	}
+
+	if functionName != "" {
+		return tokenTarget{functionID: &id, region: region}, nil
+	}
+
+	return tokenTarget{namespaceID: &id, region: region}, nil
+}
+
+type CreateFunctionTokenRequest struct {
+	Scope
+
+	FunctionName  string `json:"function_name,omitempty"`
+	NamespaceName string `json:"namespace_name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	ExpiresIn     string `json:"expires_in,omitempty"`
+}
+
+func (t *Tools) CreateFunctionToken(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in CreateFunctionTokenRequest,
+) (*mcp.CallToolResult, FunctionToken, error) {
+	var expiresAt *time.Time
+
+	if in.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(in.ExpiresIn)
+		if err != nil {
+			return nil, FunctionToken{}, fmt.Errorf("parsing expires_in: %w", err)
+		}
+
+		expiresAt = scw.TimePtr(time.Now().Add(expiresIn))
+	}
+
+	target, err := resolveTokenScope(ctx, t.functionsAPI, in.Scope, in.FunctionName, in.NamespaceName, true)
+	if err != nil {
+		return nil, FunctionToken{}, err
+	}
+
+	req := &function.CreateTokenRequest{
+		Region:      target.region,
+		FunctionID:  target.functionID,
+		NamespaceID: target.namespaceID,
+		ExpiresAt:   expiresAt,
+	}
+
+	if in.Description != "" {
+		req.Description = &in.Description
+	}
+
+	token, err := t.functionsAPI.CreateToken(req, scw.WithContext(ctx))
+	if err != nil {
+		return nil, FunctionToken{}, fmt.Errorf("creating token: %w", err)
+	}
+
+	out := NewFunctionTokenFromSDK(token)
+	out.Token = token.Token
+
+	return nil, out, nil
+}
+
+type ListFunctionTokensRequest struct {
+	Scope
+
+	FunctionName  string `json:"function_name,omitempty"`
+	NamespaceName string `json:"namespace_name,omitempty"`
+}
+
+type ListFunctionTokensResponse struct {
+	Tokens []FunctionToken `json:"tokens"`
+}
+
+func (t *Tools) ListFunctionTokens(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in ListFunctionTokensRequest,
+) (*mcp.CallToolResult, ListFunctionTokensResponse, error) {
+	target, err := resolveTokenScope(ctx, t.functionsAPI, in.Scope, in.FunctionName, in.NamespaceName, false)
+	if err != nil {
+		return nil, ListFunctionTokensResponse{}, err
+	}
+
+	resp, err := t.functionsAPI.ListTokens(&function.ListTokensRequest{
+		Region:      target.region,
+		FunctionID:  target.functionID,
+		NamespaceID: target.namespaceID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, ListFunctionTokensResponse{}, fmt.Errorf("listing tokens: %w", err)
+	}
+
+	tokens := make([]FunctionToken, 0, len(resp.Tokens))
+	for _, token := range resp.Tokens {
+		tokens = append(tokens, NewFunctionTokenFromSDK(token))
+	}
+
+	return nil, ListFunctionTokensResponse{Tokens: tokens}, nil
+}
+
+type RevokeFunctionTokenRequest struct {
+	Scope
+
+	TokenID string `json:"token_id"`
+}
+
+func (t *Tools) RevokeFunctionToken(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in RevokeFunctionTokenRequest,
+) (*mcp.CallToolResult, FunctionToken, error) {
+	token, err := t.functionsAPI.GetToken(&function.GetTokenRequest{
+		Region:  in.Region,
+		TokenID: in.TokenID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, FunctionToken{}, fmt.Errorf("getting token: %w", err)
+	}
+
+	if err := t.checkTokenOwnership(ctx, in.Region, token); err != nil {
+		return nil, FunctionToken{}, err
+	}
+
+	token, err = t.functionsAPI.DeleteToken(&function.DeleteTokenRequest{
+		Region:  in.Region,
+		TokenID: token.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, FunctionToken{}, fmt.Errorf("deleting token: %w", err)
+	}
+
+	return nil, NewFunctionTokenFromSDK(token), nil
+}
+
+// checkTokenOwnership checks that the function or the namespace of the token was created by this tool.
+func (t *Tools) checkTokenOwnership(ctx context.Context, region scw.Region, token *function.Token) error {
+	if token.FunctionID != nil {
+		fun, err := t.functionsAPI.GetFunction(&function.GetFunctionRequest{
+			Region:     region,
+			FunctionID: *token.FunctionID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("getting function of token: %w", err)
+		}
+
+		return checkResourceOwnership(fun.Tags)
+	}
+
+	ns, err := t.functionsAPI.GetNamespace(&function.GetNamespaceRequest{
+		Region:      region,
+		NamespaceID: valueOrDefault(token.NamespaceID, ""),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("getting namespace of token: %w", err)
+	}
+
+	return checkResourceOwnership(ns.Tags)
+}
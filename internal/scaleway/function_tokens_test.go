@@ -0,0 +1,176 @@
+package scaleway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const someTokenID = "some-token-id"
+
+func TestTools_CreateFunctionToken(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		givenFunction *function.Function
+		req           CreateFunctionTokenRequest
+		shouldCreate  bool
+		wantError     require.ErrorAssertionFunc
+	}{
+		{
+			name: "function and namespace both set",
+			req: CreateFunctionTokenRequest{
+				FunctionName:  fixed.SomeFunctionName,
+				NamespaceName: fixed.SomeNamespaceName,
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name: "disallow creating token for function not owned by tool",
+			givenFunction: &function.Function{
+				ID:   fixed.SomeFunctionID,
+				Name: fixed.SomeFunctionName,
+			},
+			req: CreateFunctionTokenRequest{
+				FunctionName: fixed.SomeFunctionName,
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrResourceNotOwnedByTool)
+			},
+		},
+		{
+			name: "success",
+			givenFunction: &function.Function{
+				ID:   fixed.SomeFunctionID,
+				Name: fixed.SomeFunctionName,
+				Tags: []string{constants.TagCreatedByScalewayMCP},
+			},
+			req: CreateFunctionTokenRequest{
+				FunctionName: fixed.SomeFunctionName,
+				Description:  "CI",
+				ExpiresIn:    "24h",
+			},
+			shouldCreate: true,
+			wantError:    require.NoError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+			if tc.givenFunction != nil {
+				mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+					Name: &tc.req.FunctionName,
+				}, mock.Anything).Return(&function.ListFunctionsResponse{
+					Functions: []*function.Function{tc.givenFunction},
+				}, nil).Once()
+			}
+
+			if tc.shouldCreate {
+				mockFunctionsAPI.EXPECT().CreateToken(mock.MatchedBy(func(req *function.CreateTokenRequest) bool {
+					return *req.FunctionID == fixed.SomeFunctionID &&
+						req.NamespaceID == nil &&
+						*req.Description == "CI" &&
+						time.Until(*req.ExpiresAt) > 23*time.Hour
+				}), mock.Anything).Return(&function.Token{
+					ID:         someTokenID,
+					Token:      someFunctionToken,
+					FunctionID: scw.StringPtr(fixed.SomeFunctionID),
+					Status:     function.TokenStatusReady,
+				}, nil).Once()
+			}
+
+			tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+			_, got, err := tools.CreateFunctionToken(t.Context(), nil, tc.req)
+			tc.wantError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, someFunctionToken, got.Token)
+			assert.Equal(t, fixed.SomeFunctionID, got.FunctionID)
+		})
+	}
+}
+
+func TestTools_RevokeFunctionToken(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name           string
+		givenNamespace *function.Namespace
+		shouldDelete   bool
+		wantError      require.ErrorAssertionFunc
+	}{
+		{
+			name: "disallow revoking token of namespace not owned by tool",
+			givenNamespace: &function.Namespace{
+				ID:   fixed.SomeNamespaceID,
+				Tags: []string{"some-other-tag"},
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrResourceNotOwnedByTool)
+			},
+		},
+		{
+			name: "success",
+			givenNamespace: &function.Namespace{
+				ID:   fixed.SomeNamespaceID,
+				Tags: []string{constants.TagCreatedByScalewayMCP},
+			},
+			shouldDelete: true,
+			wantError:    require.NoError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+			token := &function.Token{
+				ID:          someTokenID,
+				NamespaceID: scw.StringPtr(fixed.SomeNamespaceID),
+				Status:      function.TokenStatusReady,
+			}
+
+			mockFunctionsAPI.EXPECT().GetToken(&function.GetTokenRequest{
+				TokenID: someTokenID,
+			}, mock.Anything).Return(token, nil).Once()
+
+			mockFunctionsAPI.EXPECT().GetNamespace(&function.GetNamespaceRequest{
+				NamespaceID: fixed.SomeNamespaceID,
+			}, mock.Anything).Return(tc.givenNamespace, nil).Once()
+
+			if tc.shouldDelete {
+				mockFunctionsAPI.EXPECT().DeleteToken(&function.DeleteTokenRequest{
+					TokenID: someTokenID,
+				}, mock.Anything).Return(token, nil).Once()
+			}
+
+			tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+			_, _, err := tools.RevokeFunctionToken(t.Context(), nil, RevokeFunctionTokenRequest{
+				TokenID: someTokenID,
+			})
+			tc.wantError(t, err)
+		})
+	}
+}
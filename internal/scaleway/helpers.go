@@ -16,15 +16,19 @@ import (
 var (
 	ErrResourceNotFound       = errors.New("resource not found")
 	ErrResourceNotOwnedByTool = errors.New("resource not owned by this tool")
+	ErrInvalidValue           = errors.New("invalid value")
 )
 
 func getFunctionNamespaceByName(
 	ctx context.Context,
 	functionAPI FunctionAPI,
+	scope Scope,
 	name string,
 ) (*function.Namespace, error) {
 	resp, err := functionAPI.ListNamespaces(&function.ListNamespacesRequest{
-		Name: &name,
+		Region:    scope.Region,
+		Name:      &name,
+		ProjectID: scope.projectIDFilter(),
 	}, scw.WithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("listing namespaces: %w", err)
@@ -42,10 +46,13 @@ func getFunctionNamespaceByName(
 func getFunctionByName(
 	ctx context.Context,
 	functionAPI FunctionAPI,
+	scope Scope,
 	name string,
 ) (*function.Function, error) {
 	resp, err := functionAPI.ListFunctions(&function.ListFunctionsRequest{
-		Name: &name,
+		Region:    scope.Region,
+		Name:      &name,
+		ProjectID: scope.projectIDFilter(),
 	}, scw.WithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("listing functions: %w", err)
@@ -60,17 +67,39 @@ func getFunctionByName(
 	return functions[0], nil
 }
 
+// getOwnedFunctionByName returns the function, if it was created by this tool.
+// Resources without tags, like CRON triggers, rely on the ownership of their function.
+func getOwnedFunctionByName(
+	ctx context.Context,
+	functionAPI FunctionAPI,
+	scope Scope,
+	name string,
+) (*function.Function, error) {
+	fun, err := getFunctionByName(ctx, functionAPI, scope, name)
+	if err != nil {
+		return nil, fmt.Errorf("getting function by name: %w", err)
+	}
+
+	if err := checkResourceOwnership(fun.Tags); err != nil {
+		return nil, err
+	}
+
+	return fun, nil
+}
+
 func getFunctionAndNamespaceByFunctionName(
 	ctx context.Context,
 	functionAPI FunctionAPI,
+	scope Scope,
 	functionName string,
 ) (*function.Function, *function.Namespace, error) {
-	fun, err := getFunctionByName(ctx, functionAPI, functionName)
+	fun, err := getFunctionByName(ctx, functionAPI, scope, functionName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("getting function by name: %w", err)
 	}
 
 	ns, err := functionAPI.GetNamespace(&function.GetNamespaceRequest{
+		Region:      fun.Region,
 		NamespaceID: fun.NamespaceID,
 	}, scw.WithContext(ctx))
 	if err != nil {
@@ -139,11 +168,13 @@ type WaitForFunctionCallback func(fun *function.Function)
 func waitForFunction(
 	ctx context.Context,
 	functionAPI FunctionAPI,
+	region scw.Region,
 	functionID string,
 	cb WaitForFunctionCallback,
 ) (*function.Function, error) {
 	for {
 		fun, err := functionAPI.GetFunction(&function.GetFunctionRequest{
+			Region:     region,
 			FunctionID: functionID,
 		}, scw.WithContext(ctx))
 		if err != nil {
@@ -179,3 +210,27 @@ func waitForFunction(
 		}
 	}
 }
+
+func parsePrivacy(privacy string) (function.FunctionPrivacy, error) {
+	return parseEnum("privacy", privacy, function.FunctionPrivacyPublic, function.FunctionPrivacyPrivate)
+}
+
+func parseHTTPOption(httpOption string) (function.FunctionHTTPOption, error) {
+	return parseEnum("http_option", httpOption, function.FunctionHTTPOptionEnabled, function.FunctionHTTPOptionRedirected)
+}
+
+// parseEnum checks that the value is one of the allowed ones. An empty value is returned as is,
+// which leaves the field unset.
+func parseEnum[T ~string](field, value string, allowed ...T) (T, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	for _, a := range allowed {
+		if strings.EqualFold(value, string(a)) {
+			return a, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w for %q: %q, expected one of %v", ErrInvalidValue, field, value, allowed)
+}
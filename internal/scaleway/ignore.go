@@ -0,0 +1,297 @@
+package scaleway
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+)
+
+const (
+	gitIgnoreFile = ".gitignore"
+	scwIgnoreFile = ".scwignore"
+)
+
+//nolint:gochecknoglobals
+var (
+	// defaultIgnorePatterns are excluded from every code archive. They are either useless
+	// at runtime, or files that should never leave the developer's machine.
+	defaultIgnorePatterns = []string{
+		".git/",
+		".hg/",
+		".svn/",
+		".DS_Store",
+		".idea/",
+		".vscode/",
+		gitIgnoreFile,
+		scwIgnoreFile,
+		// Python
+		"__pycache__/",
+		"*.py[cod]",
+		".venv/",
+		".pytest_cache/",
+		".mypy_cache/",
+		".ruff_cache/",
+		// Node.js
+		"**/node_modules/.cache/",
+		// Local secrets
+		".env",
+		".env.*",
+	}
+
+	// dependencyFolderPatterns re-include the folders where dependencies are installed.
+	// They're usually listed in .gitignore files, but functions can't run without them.
+	// They can still be excluded through a .scwignore file.
+	dependencyFolderPatterns = []string{
+		"!node_modules/",
+		"!/" + constants.PythonPackageFolder + "/",
+	}
+)
+
+// ignoreMatcher decides which files are excluded from a code archive, using gitignore-style
+// patterns. Like in git, the last matching pattern wins.
+// Reference: https://git-scm.com/docs/gitignore#_pattern_format
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// loadIgnoreMatcher builds the matcher for a function directory, from the default patterns,
+// the .gitignore files and the .scwignore file at the root of the directory (in that order).
+// Like in git, the patterns of a nested .gitignore file are relative to its directory, and
+// take precedence over the ones of the parent directories.
+func loadIgnoreMatcher(root string) (*ignoreMatcher, error) {
+	m := &ignoreMatcher{}
+	m.addPatterns(defaultIgnorePatterns)
+
+	if err := m.addGitIgnoreFiles(root); err != nil {
+		return nil, err
+	}
+
+	m.addPatterns(dependencyFolderPatterns)
+
+	scwIgnorePatterns, err := readIgnoreFile(root, scwIgnoreFile)
+	if err != nil {
+		return nil, err
+	}
+
+	m.addPatterns(scwIgnorePatterns)
+
+	return m, nil
+}
+
+// addGitIgnoreFiles adds the patterns of the .gitignore files of the directory and its
+// subdirectories, parents first. Like git, ignored directories are not searched.
+// The dependency folders are not searched either: the .gitignore files of the packages
+// they hold are not meant for the function.
+func (m *ignoreMatcher) addGitIgnoreFiles(root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(root, p)
+		if err != nil {
+			return fmt.Errorf("getting relative path: %w", err)
+		}
+
+		base := filepath.ToSlash(relativePath)
+		if base == "." {
+			base = ""
+		} else if m.isIgnored(base, true) || isDependencyFolder(base) {
+			return filepath.SkipDir
+		}
+
+		patterns, err := readIgnoreFile(root, path.Join(base, gitIgnoreFile))
+		if err != nil {
+			return err
+		}
+
+		m.addPatternsIn(base, patterns)
+
+		return nil
+	})
+}
+
+func isDependencyFolder(relativePath string) bool {
+	return path.Base(relativePath) == "node_modules" || relativePath == constants.PythonPackageFolder
+}
+
+func readIgnoreFile(root, name string) ([]string, error) {
+	// We use os.OpenInRoot to avoid local inclusion vulnerabilities.
+	file, err := os.OpenInRoot(root, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", name, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var patterns []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	return patterns, nil
+}
+
+func (m *ignoreMatcher) addPatterns(patterns []string) {
+	m.addPatternsIn("", patterns)
+}
+
+// addPatternsIn adds patterns relative to a subdirectory (slash-separated, empty for the root).
+func (m *ignoreMatcher) addPatternsIn(base string, patterns []string) {
+	for _, pattern := range patterns {
+		if rule, ok := parseIgnorePattern(base, pattern); ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+}
+
+// isIgnored reports whether the path (relative to the root, slash-separated) is excluded.
+func (m *ignoreMatcher) isIgnored(relativePath string, isDir bool) bool {
+	ignored := false
+
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		if rule.re.MatchString(relativePath) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+func parseIgnorePattern(base, pattern string) (ignoreRule, bool) {
+	// Trailing spaces are ignored unless escaped.
+	if !strings.HasSuffix(pattern, `\ `) {
+		pattern = strings.TrimRight(pattern, " \t\r")
+	}
+
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+
+	if after, found := strings.CutPrefix(pattern, "!"); found {
+		rule.negate = true
+		pattern = after
+	}
+
+	if after, found := strings.CutSuffix(pattern, "/"); found {
+		rule.dirOnly = true
+		pattern = after
+	}
+
+	// A slash at the beginning or in the middle anchors the pattern to the directory of the
+	// ignore file. Otherwise, it matches at any depth below it.
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	if pattern == "" {
+		return ignoreRule{}, false
+	}
+
+	prefix := "^"
+	if base != "" {
+		prefix += regexp.QuoteMeta(base + "/")
+	}
+
+	if !anchored {
+		prefix += "(?:.*/)?"
+	}
+
+	re, err := regexp.Compile(prefix + ignorePatternToRegexp(pattern) + "$")
+	if err != nil {
+		// Invalid patterns are silently skipped, like git does.
+		return ignoreRule{}, false
+	}
+
+	rule.re = re
+
+	return rule, true
+}
+
+//nolint:revive,cyclop // a small state machine reads better in a single function.
+func ignorePatternToRegexp(pattern string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case strings.HasPrefix(pattern[i:], "**/"):
+			// Matches in all directories, including the current one.
+			sb.WriteString("(?:.*/)?")
+
+			i += 2
+		case strings.HasPrefix(pattern[i:], "/**") && i+3 == len(pattern):
+			// Matches everything inside.
+			sb.WriteString("/.*")
+
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end == -1 {
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+
+				continue
+			}
+
+			class := pattern[i+1 : i+1+end]
+			if after, found := strings.CutPrefix(class, "!"); found {
+				class = "^" + after
+			}
+
+			sb.WriteString("[" + class + "]")
+
+			i += end + 1
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+package scaleway
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreMatcher_isIgnored(t *testing.T) {
+	t.Parallel()
+
+	m := &ignoreMatcher{}
+	m.addPatterns([]string{
+		"# comment",
+		"*.log",
+		"!keep.log",
+		"/build/",
+		"docs/**/*.md",
+		"tmp/**",
+		"cache?",
+		"[Tt]humbs.db",
+	})
+
+	tt := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{path: "debug.log", want: true},
+		{path: "nested/debug.log", want: true},
+		{path: "keep.log", want: false},
+		{path: "build", isDir: true, want: true},
+		{path: "build", isDir: false, want: false},
+		{path: "src/build", isDir: true, want: false},
+		{path: "docs/README.md", want: true},
+		{path: "docs/a/b/README.md", want: true},
+		{path: "README.md", want: false},
+		{path: "tmp/a/b", want: true},
+		{path: "cache1", want: true},
+		{path: "cache12", want: false},
+		{path: "Thumbs.db", want: true},
+		{path: "handler.py", want: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, m.isIgnored(tc.path, tc.isDir))
+		})
+	}
+}
+
+func TestNewCodeArchive_IgnoresFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"handler.py":                      "def handle(event, context): pass",
+		".env":                            "SECRET=hunter2",
+		".git/HEAD":                       "ref: refs/heads/main",
+		"__pycache__/handler.cpython.pyc": "",
+		"package/requests/__init__.py":    "",
+		"node_modules/sharp/index.js":     "",
+		"node_modules/.cache/big.bin":     "",
+		"notes.txt":                       "",
+		"debug.log":                       "",
+		"important.log":                   "",
+		".gitignore":                      "*.log\nnode_modules/\npackage/\nnotes.txt\n",
+		".scwignore":                      "!important.log\n",
+		// Nested .gitignore files are relative to their directory.
+		"src/.gitignore":    "build/\n/local.txt\n",
+		"src/build/out.js":  "",
+		"src/local.txt":     "",
+		"src/sub/local.txt": "",
+		"local.txt":         "",
+		// The .gitignore files of dependencies are not applied.
+		"node_modules/sharp/.gitignore": "*.js\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	archive, err := NewCodeArchive(dir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.Remove(archive.Path) })
+
+	zipReader, err := zip.OpenReader(archive.Path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = zipReader.Close() })
+
+	got := make([]string, 0, len(zipReader.File))
+	for _, f := range zipReader.File {
+		got = append(got, f.Name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"handler.py",
+		"important.log",
+		"node_modules/sharp/index.js",
+		"package/requests/__init__.py",
+		"src/sub/local.txt",
+		"local.txt",
+	}, got)
+}
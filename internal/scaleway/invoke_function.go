@@ -0,0 +1,236 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// maxInvokeResponseBodySize avoids flooding the context of the LLM with large responses.
+	maxInvokeResponseBodySize = 16 * 1024 // 16KB
+	invokeTokenLifetime       = 5 * time.Minute
+	invokeTimeout             = 2 * time.Minute
+	invokeTokenPollInterval   = 500 * time.Millisecond
+	invokeTokenDeleteTimeout  = 10 * time.Second
+	// Reference: https://www.scaleway.com/en/docs/serverless-functions/how-to/secure-a-function/
+	functionAuthTokenHeader = "X-Auth-Token"
+)
+
+var (
+	ErrFunctionHasNoEndpoint = errors.New("function has no endpoint")
+	ErrInvokeTokenNotReady   = errors.New("function token is not ready")
+)
+
+//nolint:gochecknoglobals
+var invokeFunctionTool = &mcp.Tool{
+	Name: "invoke_function",
+	Description: `Send an HTTP request to a deployed Scaleway Function and return its response.
+
+		- "method" defaults to GET, and "path" to "/".
+		- The response body is truncated to 16KB.
+		- Private functions are called with a short-lived token, which is deleted afterwards.`,
+}
+
+type InvokeFunctionRequest struct {
+	Scope
+
+	FunctionName string            `json:"function_name"`
+	Method       string            `json:"method,omitempty"`
+	Path         string            `json:"path,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Query        map[string]string `json:"query,omitempty"`
+	Body         string            `json:"body,omitempty"`
+}
+
+type InvokeFunctionResponse struct {
+	StatusCode    int               `json:"status_code"`
+	Headers       map[string]string `json:"headers"`
+	Body          string            `json:"body"`
+	BodyTruncated bool              `json:"body_truncated,omitempty"`
+}
+
+func (req InvokeFunctionRequest) toHTTPRequest(ctx context.Context, endpoint string) (*http.Request, error) {
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	u, err := url.Parse(endpoint + "/" + strings.TrimPrefix(req.Path, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing function URL: %w", err)
+	}
+
+	query := u.Query()
+	for k, v := range req.Query {
+		query.Set(k, v)
+	}
+
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if req.Body != "" {
+		body = strings.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	return httpReq, nil
+}
+
+func (t *Tools) InvokeFunction(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in InvokeFunctionRequest,
+) (*mcp.CallToolResult, InvokeFunctionResponse, error) {
+	fun, err := getFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, InvokeFunctionResponse{}, fmt.Errorf("getting function by name: %w", err)
+	}
+
+	if fun.DomainName == "" {
+		return nil, InvokeFunctionResponse{}, fmt.Errorf(
+			"%w: function %q is in status %q",
+			ErrFunctionHasNoEndpoint,
+			fun.Name,
+			fun.Status,
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, invokeTimeout)
+	defer cancel()
+
+	httpReq, err := in.toHTTPRequest(ctx, NewFunctionFromSDK(fun).Endpoint)
+	if err != nil {
+		return nil, InvokeFunctionResponse{}, err
+	}
+
+	if fun.Privacy == function.FunctionPrivacyPrivate && httpReq.Header.Get(functionAuthTokenHeader) == "" {
+		token, err := t.createInvokeToken(ctx, fun)
+		if err != nil {
+			return nil, InvokeFunctionResponse{}, err
+		}
+
+		defer t.deleteInvokeToken(ctx, fun.Region, token)
+
+		httpReq.Header.Set(functionAuthTokenHeader, token.Token)
+	}
+
+	resp, err := t.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, InvokeFunctionResponse{}, fmt.Errorf("invoking function: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxInvokeResponseBodySize+1))
+	if err != nil {
+		return nil, InvokeFunctionResponse{}, fmt.Errorf("reading response body: %w", err)
+	}
+
+	out := InvokeFunctionResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    make(map[string]string, len(resp.Header)),
+	}
+
+	for k, v := range resp.Header {
+		out.Headers[k] = strings.Join(v, ", ")
+	}
+
+	if len(body) > maxInvokeResponseBodySize {
+		body = body[:maxInvokeResponseBodySize]
+		out.BodyTruncated = true
+	}
+
+	out.Body = string(body)
+
+	return nil, out, nil
+}
+
+func (t *Tools) createInvokeToken(ctx context.Context, fun *function.Function) (*function.Token, error) {
+	token, err := t.functionsAPI.CreateToken(&function.CreateTokenRequest{
+		Region:      fun.Region,
+		FunctionID:  &fun.ID,
+		Description: scw.StringPtr("Short-lived token created by " + invokeFunctionTool.Name),
+		ExpiresAt:   scw.TimePtr(time.Now().Add(invokeTokenLifetime)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("creating function token: %w", err)
+	}
+
+	// The token is rejected by the function until it is ready.
+	ready, err := t.waitForInvokeToken(ctx, fun.Region, token)
+	if err != nil {
+		t.deleteInvokeToken(ctx, fun.Region, token)
+
+		return nil, err
+	}
+
+	// The secret is only returned on creation.
+	ready.Token = token.Token
+
+	return ready, nil
+}
+
+func (t *Tools) waitForInvokeToken(ctx context.Context, region scw.Region, token *function.Token) (*function.Token, error) {
+	for token.Status == function.TokenStatusCreating {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context done while waiting for function token: %w", ctx.Err())
+		case <-time.After(invokeTokenPollInterval):
+		}
+
+		var err error
+
+		token, err = t.functionsAPI.GetToken(&function.GetTokenRequest{
+			Region:  region,
+			TokenID: token.ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("getting function token: %w", err)
+		}
+	}
+
+	if token.Status != function.TokenStatusReady {
+		return nil, fmt.Errorf("%w: token is in status %q", ErrInvokeTokenNotReady, token.Status)
+	}
+
+	return token, nil
+}
+
+// deleteInvokeToken is best effort: the token expires shortly anyway.
+func (t *Tools) deleteInvokeToken(ctx context.Context, region scw.Region, token *function.Token) {
+	// The request context may already be canceled.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), invokeTokenDeleteTimeout)
+	defer cancel()
+
+	_, err := t.functionsAPI.DeleteToken(&function.DeleteTokenRequest{
+		Region:  region,
+		TokenID: token.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		slogctx.FromContext(ctx).WarnContext(ctx, "failed to delete function token",
+			"token_id", token.ID,
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,177 @@
+package scaleway
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const someFunctionToken = "some-function-token"
+
+func TestTools_InvokeFunction(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			_, _ = io.WriteString(w, strings.Repeat("a", maxInvokeResponseBodySize+1))
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Echo-Token", r.Header.Get(functionAuthTokenHeader))
+		w.WriteHeader(http.StatusCreated)
+
+		_, _ = io.WriteString(w, r.Method+" "+r.URL.RequestURI()+" "+r.Header.Get("Content-Type")+" "+string(body))
+	}))
+	t.Cleanup(server.Close)
+
+	domainName := strings.TrimPrefix(server.URL, "https://")
+
+	tt := []struct {
+		name          string
+		givenFunction *function.Function
+		req           InvokeFunctionRequest
+		want          InvokeFunctionResponse
+		wantError     require.ErrorAssertionFunc
+	}{
+		{
+			name: "public function",
+			givenFunction: &function.Function{
+				ID:         fixed.SomeFunctionID,
+				Name:       fixed.SomeFunctionName,
+				DomainName: domainName,
+				Privacy:    function.FunctionPrivacyPublic,
+			},
+			req: InvokeFunctionRequest{
+				FunctionName: fixed.SomeFunctionName,
+				Method:       "post",
+				Path:         "hello",
+				Headers:      map[string]string{"Content-Type": "application/json"},
+				Query:        map[string]string{"name": "world"},
+				Body:         `{"foo":"bar"}`,
+			},
+			want: InvokeFunctionResponse{
+				StatusCode: http.StatusCreated,
+				Body:       `POST /hello?name=world application/json {"foo":"bar"}`,
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "private function",
+			givenFunction: &function.Function{
+				ID:         fixed.SomeFunctionID,
+				Name:       fixed.SomeFunctionName,
+				DomainName: domainName,
+				Privacy:    function.FunctionPrivacyPrivate,
+			},
+			req: InvokeFunctionRequest{
+				FunctionName: fixed.SomeFunctionName,
+			},
+			want: InvokeFunctionResponse{
+				StatusCode: http.StatusCreated,
+				Body:       "GET /  ",
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "truncated body",
+			givenFunction: &function.Function{
+				ID:         fixed.SomeFunctionID,
+				Name:       fixed.SomeFunctionName,
+				DomainName: domainName,
+				Privacy:    function.FunctionPrivacyPublic,
+			},
+			req: InvokeFunctionRequest{
+				FunctionName: fixed.SomeFunctionName,
+				Path:         "/large",
+			},
+			want: InvokeFunctionResponse{
+				StatusCode:    http.StatusOK,
+				Body:          strings.Repeat("a", maxInvokeResponseBodySize),
+				BodyTruncated: true,
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "function without endpoint",
+			givenFunction: &function.Function{
+				ID:     fixed.SomeFunctionID,
+				Name:   fixed.SomeFunctionName,
+				Status: function.FunctionStatusCreated,
+			},
+			req: InvokeFunctionRequest{
+				FunctionName: fixed.SomeFunctionName,
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrFunctionHasNoEndpoint)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+			mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+				Name: &tc.req.FunctionName,
+			}, mock.Anything).Return(&function.ListFunctionsResponse{
+				Functions: []*function.Function{tc.givenFunction},
+			}, nil).Once()
+
+			if tc.givenFunction.Privacy == function.FunctionPrivacyPrivate {
+				mockFunctionsAPI.EXPECT().CreateToken(mock.MatchedBy(func(req *function.CreateTokenRequest) bool {
+					return *req.FunctionID == tc.givenFunction.ID && req.ExpiresAt != nil
+				}), mock.Anything).Return(&function.Token{
+					ID:     "some-token-id",
+					Token:  someFunctionToken,
+					Status: function.TokenStatusCreating,
+				}, nil).Once()
+
+				// The token is only used once it is ready.
+				mockFunctionsAPI.EXPECT().GetToken(&function.GetTokenRequest{
+					TokenID: "some-token-id",
+				}, mock.Anything).Return(&function.Token{
+					ID:     "some-token-id",
+					Status: function.TokenStatusReady,
+				}, nil).Once()
+
+				mockFunctionsAPI.EXPECT().DeleteToken(&function.DeleteTokenRequest{
+					TokenID: "some-token-id",
+				}, mock.Anything).Return(&function.Token{}, nil).Once()
+			}
+
+			tools := &Tools{
+				functionsAPI: mockFunctionsAPI,
+				httpClient:   server.Client(),
+			}
+
+			_, got, err := tools.InvokeFunction(t.Context(), nil, tc.req)
+			tc.wantError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.want.StatusCode, got.StatusCode)
+			assert.Equal(t, tc.want.Body, got.Body)
+			assert.Equal(t, tc.want.BodyTruncated, got.BodyTruncated)
+
+			if tc.givenFunction.Privacy == function.FunctionPrivacyPrivate {
+				assert.Equal(t, someFunctionToken, got.Headers["X-Echo-Token"])
+			}
+		})
+	}
+}
@@ -6,16 +6,20 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 //nolint:gochecknoglobals
 var listFunctionNamespacesTool = &mcp.Tool{
 	Name:        "list_function_namespaces",
-	Description: "List available Scaleway Function namespaces",
+	Description: `List available Scaleway Function namespaces. Set "region" to "all" to list the namespaces of all the regions.`,
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
 }
 
-type ListFunctionNamespacesRequest struct{}
+type ListFunctionNamespacesRequest struct {
+	ListScope
+}
 
 type ListFunctionNamespacesResponse struct {
 	Namespaces []Namespace `json:"namespaces"`
@@ -24,11 +28,14 @@ type ListFunctionNamespacesResponse struct {
 func (t *Tools) ListFunctionNamespaces(
 	ctx context.Context,
 	_ *mcp.CallToolRequest,
-	_ ListFunctionNamespacesRequest,
+	in ListFunctionNamespacesRequest,
 ) (*mcp.CallToolResult, ListFunctionNamespacesResponse, error) {
 	resp, err := t.functionsAPI.ListNamespaces(
-		&function.ListNamespacesRequest{},
-		scw.WithContext(ctx),
+		&function.ListNamespacesRequest{
+			Region:    in.region(),
+			ProjectID: in.projectIDFilter(),
+		},
+		in.listOptions(ctx, t.functionsAPI)...,
 	)
 	if err != nil {
 		return nil, ListFunctionNamespacesResponse{}, fmt.Errorf("listing namespaces: %w", err)
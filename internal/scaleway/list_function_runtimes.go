@@ -13,9 +13,14 @@ import (
 var listFunctionRuntimesTool = &mcp.Tool{
 	Name:        "list_function_runtimes",
 	Description: "List available Scaleway Function runtimes",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
 }
 
-type ListFunctionRuntimesRequest struct{}
+type ListFunctionRuntimesRequest struct {
+	Scope
+}
 
 type ListFunctionRuntimesResponse struct {
 	Runtimes []Runtime `json:"runtimes"`
@@ -24,10 +29,12 @@ type ListFunctionRuntimesResponse struct {
 func (t *Tools) ListFunctionRuntimes(
 	ctx context.Context,
 	_ *mcp.CallToolRequest,
-	_ ListFunctionRuntimesRequest,
+	in ListFunctionRuntimesRequest,
 ) (*mcp.CallToolResult, ListFunctionRuntimesResponse, error) {
 	resp, err := t.functionsAPI.ListFunctionRuntimes(
-		&function.ListFunctionRuntimesRequest{},
+		&function.ListFunctionRuntimesRequest{
+			Region: in.Region,
+		},
 		scw.WithContext(ctx),
 	)
 	if err != nil {
@@ -3,20 +3,26 @@ package scaleway
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// maxConcurrentDomainLookups bounds the number of functions whose domains are listed at once.
+const maxConcurrentDomainLookups = 8
+
 //nolint:gochecknoglobals
 var listFunctionsTool = &mcp.Tool{
 	Name:        "list_functions",
-	Description: "List Scaleway Functions",
+	Description: `List Scaleway Functions. Set "region" to "all" to list the functions of all the regions.`,
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
 }
 
 type ListFunctionsRequest struct {
-	function.ListFunctionsRequest
+	ListScope
 }
 
 type ListFunctionsResponse struct {
@@ -29,19 +35,33 @@ func (t *Tools) ListFunctions(
 	in ListFunctionsRequest,
 ) (*mcp.CallToolResult, ListFunctionsResponse, error) {
 	resp, err := t.functionsAPI.ListFunctions(
-		&in.ListFunctionsRequest,
-		scw.WithAllPages(),
-		scw.WithContext(ctx),
+		&function.ListFunctionsRequest{
+			Region:    in.region(),
+			ProjectID: in.projectIDFilter(),
+		},
+		in.listOptions(ctx, t.functionsAPI)...,
 	)
 	if err != nil {
 		return nil, ListFunctionsResponse{}, fmt.Errorf("listing functions: %w", err)
 	}
 
-	functions := make([]Function, 0, len(resp.Functions))
+	functions := make([]Function, len(resp.Functions))
+
+	// Domains can only be listed per function, so the lookups are sent concurrently.
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, maxConcurrentDomainLookups)
 
-	for _, f := range resp.Functions {
-		functions = append(functions, NewFunctionFromSDK(f))
+	for i, f := range resp.Functions {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			functions[i] = t.newFunctionWithHostnames(ctx, f)
+		})
 	}
 
+	wg.Wait()
+
 	return nil, ListFunctionsResponse{Functions: functions}, nil
 }
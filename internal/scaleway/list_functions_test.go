@@ -18,9 +18,11 @@ func TestTools_ListFunctions(t *testing.T) {
 		name           string
 		givenFunctions []*function.Function
 		givenError     error
-		req            ListFunctionsRequest
-		wantFunctions  []Function
-		wantError      require.ErrorAssertionFunc
+		// givenDomainsError fails the lookup of the custom domains.
+		givenDomainsError error
+		req               ListFunctionsRequest
+		wantFunctions     []Function
+		wantError         require.ErrorAssertionFunc
 	}{
 		{
 			name: "success",
@@ -42,6 +44,34 @@ func TestTools_ListFunctions(t *testing.T) {
 					NamespaceID: fixed.SomeNamespaceID,
 					Runtime:     "python313",
 					Endpoint:    "https://my-function-xyz.functions.fr-par.scw.cloud",
+					Hostnames: []string{
+						"my-function-xyz.functions.fr-par.scw.cloud",
+						"webhooks.example.com",
+					},
+				},
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "custom domains unknown",
+			givenFunctions: []*function.Function{
+				{
+					ID:         fixed.SomeFunctionID,
+					Name:       fixed.SomeFunctionName,
+					Status:     function.FunctionStatusReady,
+					Runtime:    function.FunctionRuntimePython313,
+					DomainName: "my-function-xyz.functions.fr-par.scw.cloud",
+				},
+			},
+			givenDomainsError: assert.AnError,
+			wantFunctions: []Function{
+				{
+					ID:        fixed.SomeFunctionID,
+					Status:    "ready",
+					Name:      fixed.SomeFunctionName,
+					Runtime:   "python313",
+					Endpoint:  "https://my-function-xyz.functions.fr-par.scw.cloud",
+					Hostnames: []string{"my-function-xyz.functions.fr-par.scw.cloud"},
 				},
 			},
 			wantError: require.NoError,
@@ -64,6 +94,14 @@ func TestTools_ListFunctions(t *testing.T) {
 					Functions: tc.givenFunctions,
 				}, tc.givenError).Once()
 
+			for _, f := range tc.givenFunctions {
+				mockFunctionsAPI.EXPECT().ListDomains(&function.ListDomainsRequest{
+					FunctionID: f.ID,
+				}, mock.Anything, mock.Anything).Return(&function.ListDomainsResponse{
+					Domains: []*function.Domain{{Hostname: "webhooks.example.com"}},
+				}, tc.givenDomainsError).Once()
+			}
+
 			tools := &Tools{functionsAPI: mockFunctionsAPI}
 
 			_, got, err := tools.ListFunctions(t.Context(), nil, tc.req)
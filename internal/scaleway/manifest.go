@@ -0,0 +1,178 @@
+package scaleway
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultManifestFile is the manifest used when no path is provided.
+	DefaultManifestFile = "scaleway-functions.yaml"
+
+	// defaultManifestFunctionTimeout matches the default timeout of Scaleway Functions.
+	defaultManifestFunctionTimeout = "5m"
+)
+
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// Manifest describes the namespaces, functions and CRON triggers of a project.
+// Functions and CRON triggers which are not in the manifest are deleted from the listed namespaces,
+// as long as they were created by this tool.
+type Manifest struct {
+	Namespaces []ManifestNamespace `json:"namespaces" yaml:"namespaces"`
+}
+
+type ManifestNamespace struct {
+	Name      string             `json:"name"                yaml:"name"`
+	Functions []ManifestFunction `json:"functions,omitempty" yaml:"functions"`
+}
+
+// ManifestFunction describes a function. Optional fields which are not set are left untouched.
+type ManifestFunction struct {
+	Name string `json:"name" yaml:"name"`
+	// Directory is relative to the manifest file.
+	Directory string `json:"directory" yaml:"directory"`
+	Runtime   string `json:"runtime"   yaml:"runtime"`
+	Handler   string `json:"handler"   yaml:"handler"`
+
+	Timeout              *string           `json:"timeout,omitempty"               yaml:"timeout"`
+	Description          *string           `json:"description,omitempty"           yaml:"description"`
+	EnvironmentVariables map[string]string `json:"environment_variables,omitempty" yaml:"environment_variables"`
+	MinScale             *uint32           `json:"min_scale,omitempty"             yaml:"min_scale"`
+	MaxScale             *uint32           `json:"max_scale,omitempty"             yaml:"max_scale"`
+	MemoryLimit          *uint32           `json:"memory_limit,omitempty"          yaml:"memory_limit"`
+	Privacy              *string           `json:"privacy,omitempty"               yaml:"privacy"`
+	HTTPOption           *string           `json:"http_option,omitempty"           yaml:"http_option"`
+
+	CronTriggers []ManifestCronTrigger `json:"cron_triggers,omitempty" yaml:"cron_triggers"`
+}
+
+type ManifestCronTrigger struct {
+	Name     string         `json:"name"               yaml:"name"`
+	Schedule string         `json:"schedule"           yaml:"schedule"`
+	Timezone string         `json:"timezone,omitempty" yaml:"timezone"`
+	Args     map[string]any `json:"args,omitempty"     yaml:"args"`
+}
+
+// LoadManifest reads and validates a manifest. The directories of the functions are resolved
+// relative to the manifest file.
+func LoadManifest(path string) (*Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening manifest: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	decoder := yaml.NewDecoder(file)
+	// Typos in field names would otherwise silently leave the field untouched.
+	decoder.KnownFields(true)
+
+	var manifest Manifest
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	baseDir := filepath.Dir(path)
+
+	for i := range manifest.Namespaces {
+		for j := range manifest.Namespaces[i].Functions {
+			fun := &manifest.Namespaces[i].Functions[j]
+			if fun.Directory != "" && !filepath.IsAbs(fun.Directory) {
+				fun.Directory = filepath.Join(baseDir, fun.Directory)
+			}
+		}
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+func (m *Manifest) validate() error {
+	namespaceNames := make(map[string]bool, len(m.Namespaces))
+	// Function names are used to find functions, so they must be unique across namespaces.
+	functionNames := make(map[string]bool)
+
+	for _, ns := range m.Namespaces {
+		if ns.Name == "" {
+			return fmt.Errorf("%w: namespace without a name", ErrInvalidManifest)
+		}
+
+		if namespaceNames[ns.Name] {
+			return fmt.Errorf("%w: duplicate namespace %q", ErrInvalidManifest, ns.Name)
+		}
+
+		namespaceNames[ns.Name] = true
+
+		for _, fun := range ns.Functions {
+			if functionNames[fun.Name] {
+				return fmt.Errorf("%w: duplicate function %q", ErrInvalidManifest, fun.Name)
+			}
+
+			functionNames[fun.Name] = true
+
+			if err := fun.validate(); err != nil {
+				return fmt.Errorf("%w: function %q: %w", ErrInvalidManifest, fun.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f ManifestFunction) validate() error {
+	required := []struct{ field, value string }{
+		{"name", f.Name},
+		{"directory", f.Directory},
+		{"runtime", f.Runtime},
+		{"handler", f.Handler},
+	}
+
+	for _, r := range required {
+		if r.value == "" {
+			return fmt.Errorf("%w: missing %q", ErrInvalidValue, r.field)
+		}
+	}
+
+	if _, err := time.ParseDuration(valueOrDefault(f.Timeout, defaultManifestFunctionTimeout)); err != nil {
+		return fmt.Errorf("parsing timeout: %w", err)
+	}
+
+	if _, err := parsePrivacy(valueOrDefault(f.Privacy, "")); err != nil {
+		return err
+	}
+
+	if _, err := parseHTTPOption(valueOrDefault(f.HTTPOption, "")); err != nil {
+		return err
+	}
+
+	triggerNames := make(map[string]bool, len(f.CronTriggers))
+
+	for _, trigger := range f.CronTriggers {
+		if trigger.Name == "" {
+			return fmt.Errorf("%w: cron trigger without a name", ErrInvalidValue)
+		}
+
+		if triggerNames[trigger.Name] {
+			return fmt.Errorf("%w: duplicate cron trigger %q", ErrInvalidValue, trigger.Name)
+		}
+
+		triggerNames[trigger.Name] = true
+
+		if _, err := convertCronScheduleToUTC(trigger.Schedule, trigger.Timezone, time.Now()); err != nil {
+			return fmt.Errorf("cron trigger %q: %w", trigger.Name, err)
+		}
+	}
+
+	return nil
+}
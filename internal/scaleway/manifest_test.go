@@ -0,0 +1,144 @@
+package scaleway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadManifest(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		manifest  string
+		want      *Manifest
+		wantError require.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			manifest: `
+namespaces:
+  - name: my-namespace
+    functions:
+      - name: my-function
+        directory: my-function
+        runtime: python313
+        handler: handler.handle
+        min_scale: 1
+        cron_triggers:
+          - name: daily
+            schedule: "0 6 * * *"
+            args: {report: daily}
+`,
+			want: &Manifest{
+				Namespaces: []ManifestNamespace{{
+					Name: "my-namespace",
+					Functions: []ManifestFunction{{
+						Name: "my-function",
+						// Filled in the test, as it depends on the temporary directory.
+						Directory: "my-function",
+						Runtime:   "python313",
+						Handler:   "handler.handle",
+						MinScale:  scw.Uint32Ptr(1),
+						CronTriggers: []ManifestCronTrigger{{
+							Name:     "daily",
+							Schedule: "0 6 * * *",
+							Args:     map[string]any{"report": "daily"},
+						}},
+					}},
+				}},
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "unknown field",
+			manifest: `
+namespaces:
+  - name: my-namespace
+    functions:
+      - name: my-function
+        directory: my-function
+        runtime: python313
+        handler: handler.handle
+        min_scales: 1
+`,
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidManifest)
+			},
+		},
+		{
+			name: "missing handler",
+			manifest: `
+namespaces:
+  - name: my-namespace
+    functions:
+      - name: my-function
+        directory: my-function
+        runtime: python313
+`,
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidManifest)
+				require.ErrorContains(t, err, `missing "handler"`)
+			},
+		},
+		{
+			name: "duplicate function across namespaces",
+			manifest: `
+namespaces:
+  - name: my-namespace
+    functions:
+      - {name: my-function, directory: a, runtime: python313, handler: handler.handle}
+  - name: my-other-namespace
+    functions:
+      - {name: my-function, directory: b, runtime: python313, handler: handler.handle}
+`,
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidManifest)
+			},
+		},
+		{
+			name: "invalid cron schedule",
+			manifest: `
+namespaces:
+  - name: my-namespace
+    functions:
+      - name: my-function
+        directory: my-function
+        runtime: python313
+        handler: handler.handle
+        cron_triggers:
+          - {name: daily, schedule: "every day"}
+`,
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrInvalidCronSchedule)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, DefaultManifestFile)
+			require.NoError(t, os.WriteFile(path, []byte(tc.manifest), 0o600))
+
+			got, err := LoadManifest(path)
+			tc.wantError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			// Directories are relative to the manifest.
+			tc.want.Namespaces[0].Functions[0].Directory = filepath.Join(dir, "my-function")
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
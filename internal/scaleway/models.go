@@ -1,6 +1,7 @@
 package scaleway
 
 import (
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
 )
 
@@ -40,28 +41,54 @@ type Function struct {
 	ID           string   `json:"id"`
 	Name         string   `json:"name"`
 	NamespaceID  string   `json:"namespace_id"`
+	Region       string   `json:"region"`
 	Description  string   `json:"description"`
 	Tags         []string `json:"tags,omitempty"`
 	Status       string   `json:"status"`
 	ErrorMessage string   `json:"error_message,omitempty"`
 	Runtime      string   `json:"runtime"`
 	Endpoint     string   `json:"endpoint,omitempty"`
+	Privacy      string   `json:"privacy,omitempty"`
+	// Hostnames holds the default hostname of the function, followed by its custom domains.
+	Hostnames []string `json:"hostnames,omitempty"`
 }
 
 func NewFunctionFromSDK(f *function.Function) Function {
+	var hostnames []string
+	if f.DomainName != "" {
+		hostnames = []string{f.DomainName}
+	}
+
 	return Function{
 		ID:           f.ID,
 		Name:         f.Name,
 		NamespaceID:  f.NamespaceID,
+		Region:       f.Region.String(),
 		Description:  valueOrDefault(f.Description, ""),
 		Tags:         f.Tags,
 		Status:       f.Status.String(),
 		ErrorMessage: valueOrDefault(f.ErrorMessage, ""),
 		Runtime:      f.Runtime.String(),
 		Endpoint:     "https://" + f.DomainName,
+		Privacy:      string(f.Privacy),
+		Hostnames:    hostnames,
+	}
+}
+
+func (f *Function) addCustomDomains(domains []*function.Domain) {
+	for _, d := range domains {
+		f.Hostnames = append(f.Hostnames, d.Hostname)
 	}
 }
 
+// FunctionDeployment is returned by the tools that deploy a function.
+// When the deployment fails, the build logs are attached to help fix the issue.
+type FunctionDeployment struct {
+	Function
+
+	BuildLogs []cockpit.Log `json:"build_logs,omitempty"`
+}
+
 func valueOrDefault[T any](ptr *T, defaultValue T) T {
 	if ptr != nil {
 		return *ptr
@@ -0,0 +1,520 @@
+package scaleway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const manifestDescription = `The manifest is a YAML file, "` + DefaultManifestFile + `" by default, such as:
+
+		"""yaml
+		namespaces:
+		  - name: my-namespace
+		    functions:
+		      - name: my-function
+		        directory: ./my-function # relative to the manifest
+		        runtime: python313
+		        handler: handler.handle
+		        timeout: 30s
+		        environment_variables: {LOG_LEVEL: info}
+		        min_scale: 0
+		        max_scale: 5
+		        memory_limit: 256
+		        privacy: public
+		        cron_triggers:
+		          - name: daily-report
+		            schedule: "0 6 * * *"
+		            timezone: Europe/Paris
+		            args: {report: daily}
+		"""
+
+		Optional function fields which are not set are left untouched.
+		Functions and CRON triggers missing from the manifest are deleted from the listed namespaces,
+		if they were created by this tool. Namespaces are never deleted.
+		Secrets are not part of the manifest: use "update_function" to set them.`
+
+const (
+	ManifestActionCreate = "create"
+	ManifestActionUpdate = "update"
+	ManifestActionDelete = "delete"
+
+	manifestResourceNamespace   = "namespace"
+	manifestResourceFunction    = "function"
+	manifestResourceCronTrigger = "cron_trigger"
+)
+
+//nolint:gochecknoglobals
+var planManifestTool = &mcp.Tool{
+	Name: "plan_manifest",
+	Description: `List the changes needed for the Scaleway Functions to match a manifest file, without applying them.
+		Use "apply_manifest" to apply them.
+
+		` + manifestDescription,
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
+}
+
+// ManifestChange is a change needed for the live resources to match the manifest.
+type ManifestChange struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	// Name is the path of the resource, e.g. "namespace/function/cron_trigger".
+	Name string `json:"name"`
+	// Fields lists the updated fields.
+	Fields []string `json:"fields,omitempty"`
+}
+
+type plannedChange struct {
+	ManifestChange
+
+	// apply returns the deployment of the function, if the change deployed one.
+	apply func(ctx context.Context, req *mcp.CallToolRequest) (*FunctionDeployment, error)
+}
+
+type PlanManifestRequest struct {
+	Scope
+
+	// ManifestPath should be absolute, as the server may not run in the project directory.
+	ManifestPath string `json:"manifest_path,omitempty"`
+}
+
+func (req PlanManifestRequest) path() string {
+	if req.ManifestPath == "" {
+		return DefaultManifestFile
+	}
+
+	return req.ManifestPath
+}
+
+type PlanManifestResponse struct {
+	Changes []ManifestChange `json:"changes"`
+}
+
+func (t *Tools) PlanManifest(
+	ctx context.Context,
+	_ *mcp.CallToolRequest,
+	in PlanManifestRequest,
+) (*mcp.CallToolResult, PlanManifestResponse, error) {
+	manifest, err := LoadManifest(in.path())
+	if err != nil {
+		return nil, PlanManifestResponse{}, err
+	}
+
+	changes, err := t.planManifest(ctx, in.Scope, manifest)
+	if err != nil {
+		return nil, PlanManifestResponse{}, err
+	}
+
+	resp := PlanManifestResponse{
+		Changes: make([]ManifestChange, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, change.ManifestChange)
+	}
+
+	return nil, resp, nil
+}
+
+// planManifest diffs the manifest against the live resources of the scope. The changes are ordered
+// so that they can be applied one after the other.
+func (t *Tools) planManifest(ctx context.Context, scope Scope, manifest *Manifest) ([]plannedChange, error) {
+	var changes []plannedChange
+
+	for _, ns := range manifest.Namespaces {
+		nsChanges, err := t.planNamespace(ctx, scope, ns)
+		if err != nil {
+			return nil, fmt.Errorf("planning namespace %q: %w", ns.Name, err)
+		}
+
+		changes = append(changes, nsChanges...)
+	}
+
+	return changes, nil
+}
+
+func (t *Tools) planNamespace(ctx context.Context, scope Scope, ns ManifestNamespace) ([]plannedChange, error) {
+	var changes []plannedChange
+
+	liveFunctions := make(map[string]*function.Function)
+
+	live, err := getFunctionNamespaceByName(ctx, t.functionsAPI, scope, ns.Name)
+
+	switch {
+	case errors.Is(err, ErrResourceNotFound):
+		changes = append(changes, plannedChange{
+			ManifestChange: ManifestChange{
+				Action:   ManifestActionCreate,
+				Resource: manifestResourceNamespace,
+				Name:     ns.Name,
+			},
+			apply: func(ctx context.Context, req *mcp.CallToolRequest) (*FunctionDeployment, error) {
+				_, _, err := t.CreateAndDeployFunctionNamespace(ctx, req, CreateAndDeployFunctionNamespace{
+					Scope: scope,
+					Name:  ns.Name,
+				})
+
+				return nil, err
+			},
+		})
+	case err != nil:
+		return nil, fmt.Errorf("getting namespace by name: %w", err)
+	default:
+		resp, err := t.functionsAPI.ListFunctions(&function.ListFunctionsRequest{
+			Region:      live.Region,
+			NamespaceID: live.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("listing functions: %w", err)
+		}
+
+		for _, fun := range resp.Functions {
+			liveFunctions[fun.Name] = fun
+		}
+	}
+
+	for _, fun := range ns.Functions {
+		funChanges, err := t.planFunction(ctx, scope, ns.Name, fun, liveFunctions[fun.Name])
+		if err != nil {
+			return nil, fmt.Errorf("planning function %q: %w", fun.Name, err)
+		}
+
+		changes = append(changes, funChanges...)
+
+		delete(liveFunctions, fun.Name)
+	}
+
+	// The remaining functions are not in the manifest anymore.
+	for _, name := range slices.Sorted(maps.Keys(liveFunctions)) {
+		// Functions created by other means are not managed by the manifest.
+		liveFun := liveFunctions[name]
+		if checkResourceOwnership(liveFun.Tags) != nil {
+			continue
+		}
+
+		changes = append(changes, plannedChange{
+			ManifestChange: ManifestChange{
+				Action:   ManifestActionDelete,
+				Resource: manifestResourceFunction,
+				Name:     ns.Name + "/" + name,
+			},
+			apply: func(ctx context.Context, _ *mcp.CallToolRequest) (*FunctionDeployment, error) {
+				_, err := t.deleteFunction(ctx, liveFun)
+
+				return nil, err
+			},
+		})
+	}
+
+	return changes, nil
+}
+
+func (t *Tools) planFunction(
+	ctx context.Context,
+	scope Scope,
+	namespaceName string,
+	fun ManifestFunction,
+	live *function.Function,
+) ([]plannedChange, error) {
+	name := namespaceName + "/" + fun.Name
+
+	if live == nil {
+		// The function is only known once created: its CRON triggers are created right after it.
+		var created *function.Function
+
+		changes := []plannedChange{{
+			ManifestChange: ManifestChange{
+				Action:   ManifestActionCreate,
+				Resource: manifestResourceFunction,
+				Name:     name,
+			},
+			apply: func(ctx context.Context, req *mcp.CallToolRequest) (*FunctionDeployment, error) {
+				_, deployment, err := t.CreateAndDeployFunction(ctx, req, fun.toCreateRequest(scope, namespaceName))
+				if err != nil {
+					return nil, err
+				}
+
+				created = &function.Function{
+					ID:     deployment.ID,
+					Name:   deployment.Name,
+					Region: scw.Region(deployment.Region),
+				}
+
+				return &deployment, nil
+			},
+		}}
+
+		target := func() *function.Function { return created }
+
+		return append(changes, t.planCronTriggers(target, name, fun, nil)...), nil
+	}
+
+	if err := checkResourceOwnership(live.Tags); err != nil {
+		return nil, err
+	}
+
+	archive, err := NewCodeArchive(fun.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("creating archive: %w", err)
+	}
+
+	_ = os.Remove(archive.Path)
+
+	var changes []plannedChange
+
+	if fields, updateReq := fun.diff(scope, live, archive.Digest); len(fields) > 0 {
+		changes = append(changes, plannedChange{
+			ManifestChange: ManifestChange{
+				Action:   ManifestActionUpdate,
+				Resource: manifestResourceFunction,
+				Name:     name,
+				Fields:   fields,
+			},
+			apply: func(ctx context.Context, req *mcp.CallToolRequest) (*FunctionDeployment, error) {
+				deployment, err := t.updateFunction(ctx, req, live, updateReq)
+
+				return &deployment, err
+			},
+		})
+	}
+
+	liveCrons, err := listCrons(ctx, t.functionsAPI, live)
+	if err != nil {
+		return nil, err
+	}
+
+	target := func() *function.Function { return live }
+
+	return append(changes, t.planCronTriggers(target, name, fun, liveCrons)...), nil
+}
+
+func (f ManifestFunction) toCreateRequest(scope Scope, namespaceName string) CreateAndDeployFunctionRequest {
+	return CreateAndDeployFunctionRequest{
+		Scope:                scope,
+		Directory:            f.Directory,
+		FunctionName:         f.Name,
+		NamespaceName:        namespaceName,
+		Runtime:              f.Runtime,
+		Handler:              f.Handler,
+		Timeout:              valueOrDefault(f.Timeout, defaultManifestFunctionTimeout),
+		Description:          valueOrDefault(f.Description, ""),
+		EnvironmentVariables: f.EnvironmentVariables,
+		MinScale:             f.MinScale,
+		MaxScale:             f.MaxScale,
+		MemoryLimit:          f.MemoryLimit,
+		Privacy:              valueOrDefault(f.Privacy, ""),
+		HTTPOption:           valueOrDefault(f.HTTPOption, ""),
+	}
+}
+
+// diff returns the fields which differ from the live function, and the request to update them.
+//
+//nolint:cyclop,funlen // one branch per field reads better than a generic comparison.
+func (f ManifestFunction) diff(
+	scope Scope,
+	live *function.Function,
+	codeArchiveDigest string,
+) ([]string, UpdateFunctionRequest) {
+	var fields []string
+
+	req := UpdateFunctionRequest{
+		Scope:        scope,
+		Directory:    f.Directory,
+		FunctionName: f.Name,
+	}
+
+	if digest, _ := getCodeArchiveDigestFromTags(live.Tags); digest != codeArchiveDigest {
+		fields = append(fields, "code")
+	}
+
+	if !strings.EqualFold(f.Runtime, live.Runtime.String()) {
+		fields = append(fields, "runtime")
+		req.Runtime = &f.Runtime
+	}
+
+	if f.Handler != live.Handler {
+		fields = append(fields, "handler")
+		req.Handler = &f.Handler
+	}
+
+	if f.Timeout != nil {
+		// Already validated when loading the manifest.
+		timeout, _ := time.ParseDuration(*f.Timeout)
+		if live.Timeout == nil || int64(timeout.Seconds()) != live.Timeout.Seconds {
+			fields = append(fields, "timeout")
+			req.Timeout = f.Timeout
+		}
+	}
+
+	if f.Description != nil && *f.Description != valueOrDefault(live.Description, "") {
+		fields = append(fields, "description")
+		req.Description = f.Description
+	}
+
+	if f.EnvironmentVariables != nil && !maps.Equal(f.EnvironmentVariables, live.EnvironmentVariables) {
+		fields = append(fields, "environment_variables")
+		req.EnvironmentVariables = &f.EnvironmentVariables
+	}
+
+	if f.MinScale != nil && *f.MinScale != live.MinScale {
+		fields = append(fields, "min_scale")
+		req.MinScale = f.MinScale
+	}
+
+	if f.MaxScale != nil && *f.MaxScale != live.MaxScale {
+		fields = append(fields, "max_scale")
+		req.MaxScale = f.MaxScale
+	}
+
+	if f.MemoryLimit != nil && *f.MemoryLimit != live.MemoryLimit {
+		fields = append(fields, "memory_limit")
+		req.MemoryLimit = f.MemoryLimit
+	}
+
+	// Both are already validated when loading the manifest, and may be in any case.
+	if privacy, _ := parsePrivacy(valueOrDefault(f.Privacy, "")); privacy != "" && privacy != live.Privacy {
+		fields = append(fields, "privacy")
+		req.Privacy = f.Privacy
+	}
+
+	if httpOption, _ := parseHTTPOption(valueOrDefault(f.HTTPOption, "")); httpOption != "" && httpOption != live.HTTPOption {
+		fields = append(fields, "http_option")
+		req.HTTPOption = f.HTTPOption
+	}
+
+	return fields, req
+}
+
+// planCronTriggers diffs the triggers of the manifest function against the live ones. target returns
+// the function the triggers belong to, once it exists.
+func (t *Tools) planCronTriggers(
+	target func() *function.Function,
+	name string,
+	fun ManifestFunction,
+	liveCrons []*function.Cron,
+) []plannedChange {
+	var changes []plannedChange
+
+	liveByName := make(map[string]*function.Cron, len(liveCrons))
+	for _, c := range liveCrons {
+		liveByName[c.Name] = c
+	}
+
+	for _, trigger := range fun.CronTriggers {
+		live, found := liveByName[trigger.Name]
+		delete(liveByName, trigger.Name)
+
+		if !found {
+			changes = append(changes, plannedChange{
+				ManifestChange: ManifestChange{
+					Action:   ManifestActionCreate,
+					Resource: manifestResourceCronTrigger,
+					Name:     name + "/" + trigger.Name,
+				},
+				apply: func(ctx context.Context, _ *mcp.CallToolRequest) (*FunctionDeployment, error) {
+					createReq, err := CreateCronTriggerRequest{
+						Name:     trigger.Name,
+						Schedule: trigger.Schedule,
+						Timezone: trigger.Timezone,
+						Args:     trigger.Args,
+					}.toSDK()
+					if err != nil {
+						return nil, err
+					}
+
+					_, err = t.createCron(ctx, target(), createReq)
+
+					return nil, err
+				},
+			})
+
+			continue
+		}
+
+		var fields []string
+
+		var updateReq UpdateCronTriggerRequest
+
+		// Already validated when loading the manifest.
+		schedule, _ := convertCronScheduleToUTC(trigger.Schedule, trigger.Timezone, time.Now())
+		if schedule != live.Schedule {
+			fields = append(fields, "schedule")
+			updateReq.Schedule = &trigger.Schedule
+			updateReq.Timezone = trigger.Timezone
+		}
+
+		if trigger.Args != nil && !cronArgsEqual(trigger.Args, live.Args) {
+			fields = append(fields, "args")
+			updateReq.Args = trigger.Args
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		changes = append(changes, plannedChange{
+			ManifestChange: ManifestChange{
+				Action:   ManifestActionUpdate,
+				Resource: manifestResourceCronTrigger,
+				Name:     name + "/" + trigger.Name,
+				Fields:   fields,
+			},
+			apply: func(ctx context.Context, _ *mcp.CallToolRequest) (*FunctionDeployment, error) {
+				sdkReq, err := updateReq.toSDK()
+				if err != nil {
+					return nil, err
+				}
+
+				_, err = t.updateCron(ctx, target(), live, sdkReq)
+
+				return nil, err
+			},
+		})
+	}
+
+	// The remaining triggers are not in the manifest anymore.
+	for _, cronName := range slices.Sorted(maps.Keys(liveByName)) {
+		liveCron := liveByName[cronName]
+
+		changes = append(changes, plannedChange{
+			ManifestChange: ManifestChange{
+				Action:   ManifestActionDelete,
+				Resource: manifestResourceCronTrigger,
+				Name:     name + "/" + cronName,
+			},
+			apply: func(ctx context.Context, _ *mcp.CallToolRequest) (*FunctionDeployment, error) {
+				_, err := t.deleteCron(ctx, target(), liveCron)
+
+				return nil, err
+			},
+		})
+	}
+
+	return changes
+}
+
+// cronArgsEqual compares the arguments through their JSON encoding, as numbers decoded
+// from the manifest and from the API don't have the same Go types.
+func cronArgsEqual(args map[string]any, live *scw.JSONObject) bool {
+	var liveArgs map[string]any
+	if live != nil {
+		liveArgs = *live
+	}
+
+	a, errA := json.Marshal(args)
+	b, errB := json.Marshal(liveArgs)
+
+	return errA == nil && errB == nil && string(a) == string(b)
+}
@@ -0,0 +1,128 @@
+package scaleway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_PlanManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "my-function"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my-function", "handler.py"), []byte("print(1)"), 0o600))
+
+	manifest := `
+namespaces:
+  - name: ` + fixed.SomeNamespaceName + `
+    functions:
+      - name: ` + fixed.SomeFunctionName + `
+        directory: my-function
+        runtime: python313
+        handler: handler.new_handle
+        cron_triggers:
+          - {name: daily, schedule: "0 7 * * *"}
+          - {name: weekly, schedule: "0 7 * * 1"}
+      - name: new-function
+        directory: my-function
+        runtime: python313
+        handler: handler.handle
+`
+	manifestPath := filepath.Join(dir, DefaultManifestFile)
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifest), 0o600))
+
+	ownedTags := setCodeArchiveDigestTag(
+		[]string{constants.TagCreatedByScalewayMCP},
+		archiveDigest(t, filepath.Join(dir, "my-function")),
+	)
+
+	tt := []struct {
+		name            string
+		givenNamespaces []*function.Namespace
+		want            []ManifestChange
+	}{
+		{
+			name: "namespace does not exist",
+			want: []ManifestChange{
+				{Action: "create", Resource: "namespace", Name: "my-namespace"},
+				{Action: "create", Resource: "function", Name: "my-namespace/my-function"},
+				{Action: "create", Resource: "cron_trigger", Name: "my-namespace/my-function/daily"},
+				{Action: "create", Resource: "cron_trigger", Name: "my-namespace/my-function/weekly"},
+				{Action: "create", Resource: "function", Name: "my-namespace/new-function"},
+			},
+		},
+		{
+			name:            "namespace exists",
+			givenNamespaces: []*function.Namespace{{ID: fixed.SomeNamespaceID, Name: fixed.SomeNamespaceName}},
+			want: []ManifestChange{
+				{Action: "update", Resource: "function", Name: "my-namespace/my-function", Fields: []string{"handler"}},
+				{Action: "update", Resource: "cron_trigger", Name: "my-namespace/my-function/daily", Fields: []string{"schedule"}},
+				{Action: "create", Resource: "cron_trigger", Name: "my-namespace/my-function/weekly"},
+				{Action: "delete", Resource: "cron_trigger", Name: "my-namespace/my-function/obsolete"},
+				{Action: "create", Resource: "function", Name: "my-namespace/new-function"},
+				// "manual-function" was not created by this tool, so it is left untouched.
+				{Action: "delete", Resource: "function", Name: "my-namespace/old-function"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+			mockFunctionsAPI.EXPECT().ListNamespaces(&function.ListNamespacesRequest{
+				Name: scw.StringPtr(fixed.SomeNamespaceName),
+			}, mock.Anything).Return(&function.ListNamespacesResponse{
+				Namespaces: tc.givenNamespaces,
+			}, nil).Once()
+
+			if tc.givenNamespaces != nil {
+				mockFunctionsAPI.EXPECT().ListFunctions(&function.ListFunctionsRequest{
+					NamespaceID: fixed.SomeNamespaceID,
+				}, mock.Anything, mock.Anything).Return(&function.ListFunctionsResponse{
+					Functions: []*function.Function{
+						{
+							ID:      fixed.SomeFunctionID,
+							Name:    fixed.SomeFunctionName,
+							Runtime: function.FunctionRuntimePython313,
+							Handler: "handler.handle",
+							Tags:    ownedTags,
+						},
+						{ID: "some-old-function-id", Name: "old-function", Tags: ownedTags},
+						{ID: "some-manual-function-id", Name: "manual-function"},
+					},
+				}, nil).Once()
+
+				mockFunctionsAPI.EXPECT().ListCrons(&function.ListCronsRequest{
+					FunctionID: fixed.SomeFunctionID,
+				}, mock.Anything, mock.Anything).Return(&function.ListCronsResponse{
+					Crons: []*function.Cron{
+						{ID: someCronID, Name: "daily", Schedule: "0 6 * * *"},
+						{ID: "some-obsolete-cron-id", Name: "obsolete", Schedule: "0 6 * * *"},
+					},
+				}, nil).Once()
+			}
+
+			tools := &Tools{functionsAPI: mockFunctionsAPI}
+
+			_, got, err := tools.PlanManifest(t.Context(), nil, PlanManifestRequest{
+				ManifestPath: manifestPath,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, got.Changes)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"time"
 
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
 	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
@@ -37,6 +39,11 @@ var emojiForStep = map[FunctionDeploymentStep]string{
 type FunctionDeploymentProgress struct {
 	functionName string
 	currentStep  FunctionDeploymentStep
+
+	// output receives the messages when there is no tool call to notify, e.g. in watch mode.
+	output func(message string)
+	// onBuildLog receives the build logs as they arrive. Without it, they are not followed.
+	onBuildLog LogTailCallback
 }
 
 func NewFunctionDeploymentProgress(functionName string) *FunctionDeploymentProgress {
@@ -130,19 +137,113 @@ func (p *FunctionDeploymentProgress) notifyInner(
 		"step", p.currentStep,
 		"message", message,
 	)
-	progressToken := req.Params.GetProgressToken()
+
+	logger.InfoContext(ctx, "Function deployment progressed")
+
+	if req == nil && p.output != nil {
+		p.output(message)
+	}
+
+	notifyProgress(ctx, req, message, float64(p.currentStep), float64(TotalFunctionSteps))
+}
+
+// notifyProgress sends a progress notification to the client. A total of 0 means that it's unknown.
+// Progress made outside of a tool call (e.g. in watch mode) has no request and is not sent.
+func notifyProgress(ctx context.Context, req *mcp.CallToolRequest, message string, progress, total float64) {
+	if req == nil {
+		return
+	}
 
 	params := &mcp.ProgressNotificationParams{
 		Message:       message,
-		ProgressToken: progressToken,
-		Progress:      float64(p.currentStep),
-		Total:         float64(TotalFunctionSteps),
+		ProgressToken: req.Params.GetProgressToken(),
+		Progress:      progress,
+		Total:         total,
 	}
 
-	logger.InfoContext(ctx, "Function deployment progressed")
-
 	err := req.Session.NotifyProgress(ctx, params)
 	if err != nil {
 		slog.ErrorContext(ctx, "Notifying progress", "error", err)
 	}
 }
+
+// ImagePullCallback is called for every message of an image pull.
+type ImagePullCallback func(msg imagePullMessage)
+
+// ContainerOutputCallback is called for every line written by a container.
+type ContainerOutputCallback func(line string)
+
+// DockerProgress reports the progress of the tools running Docker containers. Unlike deployments,
+// the number of steps is not known in advance, so the progress only increases with every message.
+type DockerProgress struct {
+	image    string
+	progress int
+}
+
+func NewDockerProgress(image string) *DockerProgress {
+	return &DockerProgress{image: image}
+}
+
+func (p *DockerProgress) GetImagePullCB(ctx context.Context, req *mcp.CallToolRequest) ImagePullCallback {
+	// Layers send a message for every chunk downloaded, so only status changes are notified.
+	layerStatuses := make(map[string]string)
+
+	return func(msg imagePullMessage) {
+		if msg.ID == "" {
+			p.notifyInner(ctx, req, "⬇️ "+msg.Status)
+
+			return
+		}
+
+		if layerStatuses[msg.ID] == msg.Status {
+			return
+		}
+
+		layerStatuses[msg.ID] = msg.Status
+
+		p.notifyInner(ctx, req, "⬇️ "+msg.ID+": "+msg.Status)
+	}
+}
+
+func (p *DockerProgress) GetContainerOutputCB(ctx context.Context, req *mcp.CallToolRequest) ContainerOutputCallback {
+	return func(line string) {
+		p.notifyInner(ctx, req, "🐳 "+line)
+	}
+}
+
+func (p *DockerProgress) notifyInner(ctx context.Context, req *mcp.CallToolRequest, message string) {
+	p.progress++
+
+	slogctx.FromContext(ctx).DebugContext(ctx, "Docker container progressed",
+		"image", p.image,
+		"message", message,
+	)
+
+	notifyProgress(ctx, req, message, float64(p.progress), 0)
+}
+
+// LogTailCallback is called for every new log of a tailed function.
+type LogTailCallback func(log cockpit.Log)
+
+// LogTailProgress forwards the logs of a tailed function to the client as they arrive.
+type LogTailProgress struct {
+	functionName string
+	lines        int
+}
+
+func NewLogTailProgress(functionName string) *LogTailProgress {
+	return &LogTailProgress{functionName: functionName}
+}
+
+func (p *LogTailProgress) GetLogCB(ctx context.Context, req *mcp.CallToolRequest) LogTailCallback {
+	return func(log cockpit.Log) {
+		p.lines++
+
+		slogctx.FromContext(ctx).DebugContext(ctx, "Function log received",
+			"function_name", p.functionName,
+			"timestamp", log.Timestamp,
+		)
+
+		notifyProgress(ctx, req, "📜 "+log.Timestamp.Format(time.RFC3339)+" "+log.Message, float64(p.lines), 0)
+	}
+}
@@ -0,0 +1,419 @@
+package scaleway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/namegenerator"
+)
+
+const (
+	defaultLocalRunTimeout = time.Minute
+	// maxLocalRunOutputSize only keeps the end of stdout and stderr, where errors usually are.
+	maxLocalRunOutputSize = 16 * 1024 // 16KB
+
+	// localRunResultMarker prefixes the line printed by the bootstrap scripts with the handler result.
+	localRunResultMarker = "__SCW_LOCAL_RUN_RESULT__:"
+)
+
+var ErrRuntimeLocalRunNotSupported = errors.New("running functions locally is not supported for this runtime")
+
+// The bootstrap scripts import the handler, call it with the event passed in the environment,
+// and print its result as JSON, like the Scaleway runtimes would send it back.
+const (
+	pythonLocalRunBootstrap = `
+import importlib, json, os, sys
+sys.path[:0] = ["/function", "/function/` + constants.PythonPackageFolder + `"]
+module_name, _, handler_name = os.environ["SCW_LOCAL_HANDLER"].rpartition(".")
+module = importlib.import_module(module_name.replace("/", "."))
+event = json.loads(os.environ["SCW_LOCAL_EVENT"])
+context = {"memoryLimitInMb": 128, "functionName": "local", "functionVersion": "local"}
+result = getattr(module, handler_name)(event, context)
+sys.stdout.flush()
+print("` + localRunResultMarker + `" + json.dumps(result, default=str), flush=True)
+`
+
+	nodeLocalRunBootstrap = `
+const fs = require("fs");
+const path = require("path");
+const { pathToFileURL } = require("url");
+const spec = process.env.SCW_LOCAL_HANDLER;
+const file = spec.slice(0, spec.lastIndexOf("."));
+const name = spec.slice(spec.lastIndexOf(".") + 1);
+const found = [".js", ".mjs", ".cjs"].map((ext) => path.join("/function", file + ext)).find((f) => fs.existsSync(f));
+if (!found) {
+  console.error("handler file not found: " + file);
+  process.exit(1);
+}
+const event = JSON.parse(process.env.SCW_LOCAL_EVENT);
+const context = { memoryLimitInMb: 128, functionName: "local", functionVersion: "local" };
+import(pathToFileURL(found).href)
+  .then(async (mod) => {
+    const handler = mod[name] ?? mod.default?.[name];
+    if (typeof handler !== "function") throw new Error(name + " is not exported by " + file);
+    const result = await new Promise((resolve, reject) => {
+      const r = handler(event, context, (err, res) => (err ? reject(err) : resolve(res)));
+      if (r !== undefined) Promise.resolve(r).then(resolve, reject);
+    });
+    console.log("` + localRunResultMarker + `" + JSON.stringify(result ?? null));
+  })
+  .catch((err) => {
+    console.error(err);
+    process.exit(1);
+  });
+`
+)
+
+//nolint:gochecknoglobals
+var runFunctionLocallyTool = &mcp.Tool{
+	Name: "run_function_locally",
+	Description: `Run the handler of a function from a local directory with Docker, without deploying it.
+	This is much faster than deploying the function to test a change.
+
+	- Only Python and Node.js runtimes are supported.
+	- The handler is called once with an HTTP event built from "method", "path", "headers", "query" and "body".
+	- The result holds the response of the handler, as well as what it printed on stdout and stderr.
+	- If the handler raised an error, "exit_code" is not 0 and the error is in "stderr".
+	- "timeout" defaults to 1m. When it is reached, "timed_out" is set and the output printed so far is returned.
+
+	The provided "directory" must be an existing directory where the function code is located.`,
+}
+
+type RunFunctionLocallyRequest struct {
+	Scope
+
+	Directory string `json:"directory"`
+	Runtime   string `json:"runtime"`
+	Handler   string `json:"handler"`
+
+	Method  string            `json:"method,omitempty"`
+	Path    string            `json:"path,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Query   map[string]string `json:"query,omitempty"`
+	Body    string            `json:"body,omitempty"`
+
+	EnvironmentVariables       map[string]string `json:"environment_variables,omitempty"`
+	SecretEnvironmentVariables map[string]string `json:"secret_environment_variables,omitempty"`
+	Timeout                    string            `json:"timeout,omitempty"`
+}
+
+type RunFunctionLocallyResponse struct {
+	StatusCode int               `json:"status_code,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	ExitCode   int               `json:"exit_code"`
+	Stdout     string            `json:"stdout,omitempty"`
+	Stderr     string            `json:"stderr,omitempty"`
+	// TimedOut is set when the handler was stopped after the timeout. The output is partial.
+	TimedOut bool `json:"timed_out,omitempty"`
+}
+
+// event mimics the HTTP event received by the handlers on Scaleway.
+func (req RunFunctionLocallyRequest) event() map[string]any {
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	path := req.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return map[string]any{
+		"httpMethod":            method,
+		"path":                  path,
+		"headers":               valueOrEmptyMap(req.Headers),
+		"queryStringParameters": valueOrEmptyMap(req.Query),
+		"body":                  req.Body,
+		"isBase64Encoded":       false,
+	}
+}
+
+func valueOrEmptyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+
+	return m
+}
+
+func (t *Tools) RunFunctionLocally(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	in RunFunctionLocallyRequest,
+) (*mcp.CallToolResult, RunFunctionLocallyResponse, error) {
+	timeout := defaultLocalRunTimeout
+
+	if in.Timeout != "" {
+		var err error
+
+		timeout, err = time.ParseDuration(in.Timeout)
+		if err != nil {
+			return nil, RunFunctionLocallyResponse{}, fmt.Errorf("parsing timeout: %w", err)
+		}
+	}
+
+	runtime, language, err := getRuntimeByName(ctx, t.functionsAPI, in.Region, in.Runtime)
+	if err != nil {
+		return nil, RunFunctionLocallyResponse{}, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	directory, err := filepath.Abs(in.Directory)
+	if err != nil {
+		return nil, RunFunctionLocallyResponse{}, fmt.Errorf("getting absolute path of directory: %w", err)
+	}
+
+	if _, err := os.Stat(directory); os.IsNotExist(err) {
+		return nil, RunFunctionLocallyResponse{}, fmt.Errorf("%w: %s", ErrDirectoryNotExist, in.Directory)
+	}
+
+	containerConfig, hostConfig, err := getLocalRunContainerConfigs(runtime, language, directory, in)
+	if err != nil {
+		return nil, RunFunctionLocallyResponse{}, err
+	}
+
+	if err := t.loadDockerClient(); err != nil {
+		return nil, RunFunctionLocallyResponse{}, fmt.Errorf("loading docker client: %w", err)
+	}
+
+	progress := NewDockerProgress(containerConfig.Image)
+
+	if err := pullImage(ctx, t.dockerAPI, containerConfig.Image, progress.GetImagePullCB(ctx, req)); err != nil {
+		return nil, RunFunctionLocallyResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output, err := runContainerWithOutput(ctx, t.dockerAPI, containerConfig, hostConfig)
+	if err != nil {
+		return nil, RunFunctionLocallyResponse{}, err
+	}
+
+	return nil, newRunFunctionLocallyResponse(output), nil
+}
+
+func getLocalRunContainerConfigs(
+	runtime *function.Runtime,
+	language, directory string,
+	in RunFunctionLocallyRequest,
+) (*container.Config, *container.HostConfig, error) {
+	event, err := json.Marshal(in.event())
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding event: %w", err)
+	}
+
+	env := make([]string, 0, len(in.EnvironmentVariables)+len(in.SecretEnvironmentVariables)+2)
+	for k, v := range in.EnvironmentVariables {
+		env = append(env, k+"="+v)
+	}
+
+	for k, v := range in.SecretEnvironmentVariables {
+		env = append(env, k+"="+v)
+	}
+
+	env = append(env,
+		"SCW_LOCAL_HANDLER="+in.Handler,
+		"SCW_LOCAL_EVENT="+string(event),
+	)
+
+	containerConfig := &container.Config{
+		Env:        env,
+		WorkingDir: "/function",
+	}
+
+	switch language {
+	case "python":
+		containerConfig.Image = constants.PublicRuntimesRegistry + "/python-dep:" + runtime.Version
+		containerConfig.Cmd = []string{"python", "-c", pythonLocalRunBootstrap}
+		containerConfig.Env = append(containerConfig.Env, "PYTHONUNBUFFERED=1", "PYTHONDONTWRITEBYTECODE=1")
+	case "node":
+		containerConfig.Image = nodeImage(runtime)
+		containerConfig.Cmd = []string{"node", "-e", nodeLocalRunBootstrap}
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrRuntimeLocalRunNotSupported, in.Runtime)
+	}
+
+	return containerConfig, &container.HostConfig{
+		Binds: []string{
+			// The function must not be able to modify the code.
+			directory + "/:/function:ro",
+		},
+	}, nil
+}
+
+type containerOutput struct {
+	exitCode int
+	stdout   []byte
+	stderr   []byte
+	// timedOut is set when the container was stopped because the context deadline was exceeded.
+	timedOut bool
+}
+
+// runContainerWithOutput runs the container until it exits, and returns its exit code and output.
+// When the context deadline is exceeded, the container is stopped and its partial output is returned.
+func runContainerWithOutput(
+	ctx context.Context,
+	dockerClient client.APIClient,
+	containerConfig *container.Config,
+	hostConfig *container.HostConfig,
+) (containerOutput, error) {
+	containerName := namegenerator.GetRandomName(constants.ProjectName, "local")
+	logger := slogctx.FromContext(ctx).With("container_name", containerName)
+	logger.Info("Creating Docker container")
+
+	resp, err := dockerClient.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, containerName)
+	if err != nil {
+		return containerOutput{}, fmt.Errorf("creating container: %w", err)
+	}
+
+	// The container is not auto-removed, so that its logs can be read after it exits.
+	defer func() {
+		err := dockerClient.ContainerRemove(context.WithoutCancel(ctx), resp.ID, client.ContainerRemoveOptions{
+			Force: true,
+		})
+		if err != nil {
+			logger.Warn("Failed to remove Docker container", "error", err)
+		}
+	}()
+
+	if err := dockerClient.ContainerStart(ctx, resp.ID, client.ContainerStartOptions{}); err != nil {
+		return containerOutput{}, fmt.Errorf("starting container: %w", err)
+	}
+
+	var output containerOutput
+
+	statusCh, errCh := dockerClient.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+	case err := <-errCh:
+		if err != nil && ctx.Err() == nil {
+			return containerOutput{}, fmt.Errorf("waiting for container: %w", err)
+		}
+	case status := <-statusCh:
+		output.exitCode = int(status.StatusCode)
+	}
+
+	if err := ctx.Err(); err != nil {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return containerOutput{}, fmt.Errorf("waiting for container: %w", err)
+		}
+
+		logger.Info("Stopping Docker container after timeout")
+
+		output.timedOut = true
+
+		// The output of a handler which hangs is needed to debug it, so it's still read.
+		ctx = context.WithoutCancel(ctx)
+
+		timeout := 0
+		if err := dockerClient.ContainerStop(ctx, resp.ID, client.ContainerStopOptions{Timeout: &timeout}); err != nil {
+			logger.Warn("Failed to stop Docker container", "error", err)
+		}
+	}
+
+	logs, err := dockerClient.ContainerLogs(ctx, resp.ID, client.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return containerOutput{}, fmt.Errorf("getting container logs: %w", err)
+	}
+
+	defer func() {
+		_ = logs.Close()
+	}()
+
+	var stdout, stderr bytes.Buffer
+
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, logs); err != nil {
+		return containerOutput{}, fmt.Errorf("reading container logs: %w", err)
+	}
+
+	output.stdout, output.stderr = stdout.Bytes(), stderr.Bytes()
+
+	return output, nil
+}
+
+func newRunFunctionLocallyResponse(output containerOutput) RunFunctionLocallyResponse {
+	out := RunFunctionLocallyResponse{
+		ExitCode: output.exitCode,
+		Stderr:   truncateStart(string(output.stderr), maxLocalRunOutputSize),
+		TimedOut: output.timedOut,
+	}
+
+	var printed []string
+
+	for line := range strings.Lines(string(output.stdout)) {
+		if result, found := strings.CutPrefix(line, localRunResultMarker); found {
+			out.StatusCode, out.Headers, out.Body = parseHandlerResult([]byte(result))
+
+			continue
+		}
+
+		printed = append(printed, line)
+	}
+
+	out.Stdout = truncateStart(strings.Join(printed, ""), maxLocalRunOutputSize)
+
+	return out
+}
+
+// parseHandlerResult converts the value returned by a handler to an HTTP response, like Scaleway does:
+// objects with a "statusCode" are responses, anything else is sent back as the body.
+func parseHandlerResult(result []byte) (int, map[string]string, string) {
+	var response struct {
+		StatusCode *int            `json:"statusCode"`
+		Headers    map[string]any  `json:"headers"`
+		Body       json.RawMessage `json:"body"`
+	}
+
+	if err := json.Unmarshal(result, &response); err != nil || response.StatusCode == nil {
+		return 200, nil, rawJSONToString(bytes.TrimSpace(result))
+	}
+
+	headers := make(map[string]string, len(response.Headers))
+	for k, v := range response.Headers {
+		headers[k] = fmt.Sprint(v)
+	}
+
+	return *response.StatusCode, headers, rawJSONToString(response.Body)
+}
+
+// rawJSONToString returns strings as is, and other values as JSON.
+func rawJSONToString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	if string(raw) == "null" {
+		return ""
+	}
+
+	return string(raw)
+}
+
+func truncateStart(s string, maxSize int) string {
+	if len(s) <= maxSize {
+		return s
+	}
+
+	// The cut may split a multi-byte character.
+	return "[truncated]..." + strings.ToValidUTF8(s[len(s)-maxSize:], "")
+}
@@ -0,0 +1,262 @@
+package scaleway
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockdocker"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// multiplexedLogs returns container logs as sent by the Docker API when the container has no TTY.
+func multiplexedLogs(t *testing.T, stdout, stderr string) io.ReadCloser {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	_, err := stdcopy.NewStdWriter(&buf, stdcopy.Stdout).Write([]byte(stdout))
+	require.NoError(t, err)
+
+	if stderr != "" {
+		_, err = stdcopy.NewStdWriter(&buf, stdcopy.Stderr).Write([]byte(stderr))
+		require.NoError(t, err)
+	}
+
+	return io.NopCloser(&buf)
+}
+
+func TestTools_RunFunctionLocally(t *testing.T) {
+	t.Parallel()
+
+	functionDir := t.TempDir()
+
+	tt := []struct {
+		name          string
+		req           RunFunctionLocallyRequest
+		givenExitCode int64
+		// givenHang never lets the container exit.
+		givenHang       bool
+		givenStdout     string
+		givenStderr     string
+		wantPulledImage string
+		wantEvent       map[string]any
+		want            RunFunctionLocallyResponse
+		wantError       require.ErrorAssertionFunc
+	}{
+		{
+			name: "success with python",
+			req: RunFunctionLocallyRequest{
+				Directory: functionDir,
+				Runtime:   "python3.13",
+				Handler:   "handler.handle",
+				Method:    "post",
+				Path:      "hello",
+				Body:      `{"name": "world"}`,
+			},
+			givenStdout: "some print\n" +
+				localRunResultMarker + `{"statusCode": 201, "headers": {"X-Count": 1}, "body": {"hello": "world"}}` + "\n",
+			wantPulledImage: constants.PublicRuntimesRegistry + "/python-dep:3.13",
+			wantEvent: map[string]any{
+				"httpMethod":            "POST",
+				"path":                  "/hello",
+				"headers":               map[string]any{},
+				"queryStringParameters": map[string]any{},
+				"body":                  `{"name": "world"}`,
+				"isBase64Encoded":       false,
+			},
+			want: RunFunctionLocallyResponse{
+				StatusCode: 201,
+				Headers:    map[string]string{"X-Count": "1"},
+				Body:       `{"hello": "world"}`,
+				Stdout:     "some print\n",
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "success with node returning a string",
+			req: RunFunctionLocallyRequest{
+				Directory: functionDir,
+				Runtime:   "node22",
+				Handler:   "handler.handle",
+				Query:     map[string]string{"page": "2"},
+			},
+			givenStdout:     localRunResultMarker + `"hello"` + "\n",
+			wantPulledImage: "node:22-alpine",
+			wantEvent: map[string]any{
+				"httpMethod":            "GET",
+				"path":                  "/",
+				"headers":               map[string]any{},
+				"queryStringParameters": map[string]any{"page": "2"},
+				"body":                  "",
+				"isBase64Encoded":       false,
+			},
+			want: RunFunctionLocallyResponse{
+				StatusCode: 200,
+				Body:       "hello",
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "handler error",
+			req: RunFunctionLocallyRequest{
+				Directory: functionDir,
+				Runtime:   "python3.13",
+				Handler:   "handler.handle",
+			},
+			givenExitCode:   1,
+			givenStderr:     "ZeroDivisionError: division by zero\n",
+			wantPulledImage: constants.PublicRuntimesRegistry + "/python-dep:3.13",
+			want: RunFunctionLocallyResponse{
+				ExitCode: 1,
+				Stderr:   "ZeroDivisionError: division by zero\n",
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "timeout returns the partial output",
+			req: RunFunctionLocallyRequest{
+				Directory: functionDir,
+				Runtime:   "python3.13",
+				Handler:   "handler.handle",
+				Timeout:   "10ms",
+			},
+			givenHang:       true,
+			givenStdout:     "connecting to the database...\n",
+			wantPulledImage: constants.PublicRuntimesRegistry + "/python-dep:3.13",
+			want: RunFunctionLocallyResponse{
+				Stdout:   "connecting to the database...\n",
+				TimedOut: true,
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "unsupported runtime",
+			req: RunFunctionLocallyRequest{
+				Directory: functionDir,
+				Runtime:   "rust1.85",
+				Handler:   "handler",
+			},
+			wantError: func(tt require.TestingT, err error, _ ...any) {
+				require.ErrorIs(tt, err, ErrRuntimeLocalRunNotSupported)
+			},
+		},
+		{
+			name: "directory does not exist",
+			req: RunFunctionLocallyRequest{
+				Directory: "invalid-directory",
+				Runtime:   "python3.13",
+				Handler:   "handler.handle",
+			},
+			wantError: func(tt require.TestingT, err error, _ ...any) {
+				require.ErrorIs(tt, err, ErrDirectoryNotExist)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+			mockDockerAPI := mockdocker.NewMockAPIClient(t)
+
+			tools := &Tools{
+				functionsAPI: mockFunctionsAPI,
+				dockerAPI:    mockDockerAPI,
+			}
+			tools.loadDockerAPIOnce.Do(func() {})
+
+			mockFunctionsAPI.EXPECT().
+				ListFunctionRuntimes(&function.ListFunctionRuntimesRequest{}, mock.Anything).
+				Return(listRuntimesResponse, nil).
+				Once()
+
+			if tc.wantPulledImage != "" {
+				mockDockerAPI.EXPECT().ImagePull(mock.Anything, tc.wantPulledImage, mock.Anything).
+					Return(&mockDockerImageReader{}, nil).Once()
+
+				mockDockerAPI.EXPECT().ContainerCreate(mock.Anything, mock.MatchedBy(
+					func(config *container.Config) bool {
+						if tc.wantEvent == nil {
+							return true
+						}
+
+						for _, env := range config.Env {
+							if event, ok := bytes.CutPrefix([]byte(env), []byte("SCW_LOCAL_EVENT=")); ok {
+								var got map[string]any
+								require.NoError(t, json.Unmarshal(event, &got))
+
+								return assert.Equal(t, tc.wantEvent, got)
+							}
+						}
+
+						return false
+					}),
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+				).Return(container.CreateResponse{
+					ID: fixed.SomeDockerContainerID,
+				}, nil).Once()
+
+				mockDockerAPI.EXPECT().
+					ContainerStart(mock.Anything, fixed.SomeDockerContainerID, mock.Anything).
+					Return(nil).
+					Once()
+
+				waitRespChan := make(chan container.WaitResponse, 1)
+				if !tc.givenHang {
+					waitRespChan <- container.WaitResponse{StatusCode: tc.givenExitCode}
+				}
+
+				mockDockerAPI.EXPECT().
+					ContainerWait(mock.Anything, fixed.SomeDockerContainerID, mock.Anything).
+					Return(waitRespChan, make(chan error)).
+					Once()
+
+				if tc.givenHang {
+					mockDockerAPI.EXPECT().
+						ContainerStop(mock.Anything, fixed.SomeDockerContainerID, mock.Anything).
+						Return(nil).
+						Once()
+				}
+
+				mockDockerAPI.EXPECT().
+					ContainerLogs(mock.Anything, fixed.SomeDockerContainerID, mock.Anything).
+					Return(multiplexedLogs(t, tc.givenStdout, tc.givenStderr), nil).
+					Once()
+
+				mockDockerAPI.EXPECT().
+					ContainerRemove(mock.Anything, fixed.SomeDockerContainerID, mock.Anything).
+					Return(nil).
+					Once()
+			}
+
+			_, got, err := tools.RunFunctionLocally(t.Context(), nil, tc.req)
+			tc.wantError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTruncateStart(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "short", truncateStart("short", 10))
+	assert.Equal(t, "[truncated]...6789", truncateStart("0123456789", 4))
+	// "é" is two bytes long: its second byte is dropped.
+	assert.Equal(t, "[truncated]...abc", truncateStart("éabc", 4))
+}
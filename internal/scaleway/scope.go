@@ -0,0 +1,101 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// regionAll lists the resources of all the regions, for the tools which accept a ListScope.
+const regionAll scw.Region = "all"
+
+// Scope selects the Scaleway project and region a tool works in. When they are not set,
+// resources are looked up in all the projects of the default region of the active profile,
+// and created in its default project.
+type Scope struct {
+	ProjectID string     `json:"project_id,omitempty" jsonschema:"ID of the Scaleway project, defaults to the one of the active profile"`
+	Region    scw.Region `json:"region,omitempty"     jsonschema:"Scaleway region (e.g. fr-par), defaults to the one of the active profile"`
+}
+
+// ListScope is the Scope of the listing tools, which can also list the resources of all the regions at once.
+type ListScope struct {
+	ProjectID string     `json:"project_id,omitempty" jsonschema:"ID of the Scaleway project, defaults to all the projects"`
+	Region    scw.Region `json:"region,omitempty"     jsonschema:"Scaleway region (e.g. fr-par), or \"all\" for all the regions"`
+}
+
+// scopedRequest is implemented by the tool requests which embed a Scope or a ListScope.
+type scopedRequest interface {
+	validateScope() error
+}
+
+func (s Scope) validateScope() error {
+	if s.Region == "" {
+		return nil
+	}
+
+	if _, err := scw.ParseRegion(string(s.Region)); err != nil {
+		return fmt.Errorf("%w for \"region\": %w", ErrInvalidValue, err)
+	}
+
+	return nil
+}
+
+// projectIDFilter returns the project ID to filter a listing on, or nil to list all the projects.
+func (s Scope) projectIDFilter() *string {
+	if s.ProjectID == "" {
+		return nil
+	}
+
+	return &s.ProjectID
+}
+
+func (s ListScope) validateScope() error {
+	if s.Region == regionAll {
+		return nil
+	}
+
+	return Scope(s).validateScope()
+}
+
+func (s ListScope) projectIDFilter() *string {
+	return Scope(s).projectIDFilter()
+}
+
+// listOptions returns the options of the list requests. When listing all the regions,
+// the Scaleway SDK sends one request per region and merges the results.
+func (s ListScope) listOptions(ctx context.Context, functionAPI FunctionAPI) []scw.RequestOption {
+	opts := []scw.RequestOption{scw.WithAllPages(), scw.WithContext(ctx)}
+
+	if s.Region == regionAll {
+		opts = append(opts, scw.WithRegions(functionAPI.Regions()...))
+	}
+
+	return opts
+}
+
+// region returns the region of the list requests. When listing all the regions, the
+// region of the request is replaced by each of them.
+func (s ListScope) region() scw.Region {
+	if s.Region == regionAll {
+		return ""
+	}
+
+	return s.Region
+}
+
+// withValidScope rejects the requests with an invalid scope before calling the handler.
+func withValidScope[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, in In) (*mcp.CallToolResult, Out, error) {
+		if scoped, ok := any(in).(scopedRequest); ok {
+			if err := scoped.validateScope(); err != nil {
+				var zero Out
+
+				return nil, zero, err
+			}
+		}
+
+		return handler(ctx, req, in)
+	}
+}
@@ -0,0 +1,108 @@
+package scaleway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope_validateScope(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		scope     scopedRequest
+		wantError require.ErrorAssertionFunc
+	}{
+		{
+			name:      "default region",
+			scope:     Scope{},
+			wantError: require.NoError,
+		},
+		{
+			name:      "valid region",
+			scope:     Scope{Region: scw.RegionNlAms},
+			wantError: require.NoError,
+		},
+		{
+			name:  "invalid region",
+			scope: Scope{Region: "paris"},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				assert.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name:  "all regions are only listed",
+			scope: Scope{Region: regionAll},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				assert.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
+		{
+			name:      "all regions",
+			scope:     ListScope{Region: regionAll},
+			wantError: require.NoError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.wantError(t, tc.scope.validateScope())
+		})
+	}
+}
+
+func TestTools_ListFunctionNamespaces_allRegions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// e.g. /functions/v1beta1/regions/nl-ams/namespaces
+		region := strings.Split(r.URL.Path, "/")[4]
+
+		assert.Equal(t, fixed.SomeProjectID, r.URL.Query().Get("project_id"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"namespaces": []map[string]any{{
+				"id":         region + "-namespace-id",
+				"name":       fixed.SomeNamespaceName,
+				"project_id": fixed.SomeProjectID,
+				"region":     region,
+				"status":     "ready",
+			}},
+			"total_count": 1,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	scwClient, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+	)
+	require.NoError(t, err)
+
+	tools := &Tools{functionsAPI: function.NewAPI(scwClient)}
+
+	_, got, err := tools.ListFunctionNamespaces(t.Context(), nil, ListFunctionNamespacesRequest{
+		ListScope: ListScope{ProjectID: fixed.SomeProjectID, Region: regionAll},
+	})
+	require.NoError(t, err)
+
+	regions := make([]string, 0, len(got.Namespaces))
+	for _, ns := range got.Namespaces {
+		regions = append(regions, ns.Region)
+	}
+
+	assert.Equal(t, []string{"fr-par", "nl-ams", "pl-waw"}, regions)
+}
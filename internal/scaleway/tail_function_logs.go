@@ -0,0 +1,226 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultTailDuration = 30 * time.Second
+	// maxTailDuration keeps the tool call shorter than the usual client timeouts.
+	maxTailDuration = 5 * time.Minute
+
+	tailPollInterval          = 2 * time.Second
+	defaultTailIngestionDelay = 10 * time.Second
+	// tailSummaryLines is the number of logs returned at the end of the tail.
+	tailSummaryLines = 50
+)
+
+//nolint:gochecknoglobals
+var tailFunctionLogsTool = &mcp.Tool{
+	Name: "tail_function_logs",
+	Description: `Follow the logs of a Scaleway Function for a limited duration, for instance while invoking it.
+	New logs are sent as progress notifications as soon as they are available.
+
+	- "duration" defaults to "30s", and cannot exceed "5m".
+	- "contains", "regexp" and "level" filter the logs, like in "fetch_function_logs".
+	- The result holds the number of logs received and the last 50 of them.
+	  Logs can take a few seconds to be available after being written: they are polled
+	  for 10 more seconds after the duration, and may be received out of order.
+	- If the call is cancelled, the logs received so far are returned.`,
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+	},
+}
+
+type TailFunctionLogsRequest struct {
+	Scope
+
+	FunctionName string `json:"function_name"`
+	Duration     string `json:"duration,omitempty"`
+
+	Contains string `json:"contains,omitempty"`
+	Regexp   string `json:"regexp,omitempty"`
+	Level    string `json:"level,omitempty"`
+}
+
+type TailFunctionLogsResponse struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	// Count is the number of logs received, which can be more than the returned logs.
+	Count int           `json:"count"`
+	Logs  []cockpit.Log `json:"logs"`
+}
+
+func (t *Tools) TailFunctionLogs(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	in TailFunctionLogsRequest,
+) (*mcp.CallToolResult, TailFunctionLogsResponse, error) {
+	duration := defaultTailDuration
+
+	if in.Duration != "" {
+		var err error
+
+		duration, err = time.ParseDuration(in.Duration)
+		if err != nil {
+			return nil, TailFunctionLogsResponse{}, fmt.Errorf("parsing duration: %w", err)
+		}
+
+		if duration <= 0 || duration > maxTailDuration {
+			return nil, TailFunctionLogsResponse{}, fmt.Errorf(
+				"%w: duration must be positive and at most %s", ErrInvalidValue, maxTailDuration,
+			)
+		}
+	}
+
+	query, err := FetchFunctionLogsRequest{
+		Contains:  in.Contains,
+		Regexp:    in.Regexp,
+		Level:     in.Level,
+		Limit:     maxLogsLimit,
+		Direction: string(cockpit.DirectionForward),
+	}.toLogQuery()
+	if err != nil {
+		return nil, TailFunctionLogsResponse{}, err
+	}
+
+	cockpitClient, resourceName, err := t.getFunctionCockpit(ctx, in.Scope, in.FunctionName)
+	if err != nil {
+		return nil, TailFunctionLogsResponse{}, err
+	}
+
+	resp := TailFunctionLogsResponse{
+		StartTime: time.Now().UTC(),
+		Logs:      []cockpit.Log{},
+	}
+	onLog := NewLogTailProgress(in.FunctionName).GetLogCB(ctx, req)
+
+	var (
+		// polledUntil is where the next poll starts, before going back by the ingestion delay.
+		polledUntil = resp.StartTime
+		// windowEnd is set once the duration is over, so that later logs are left out.
+		windowEnd time.Time
+		// pageFull is set when the last poll did not return all the logs of its window.
+		pageFull bool
+		// seen holds the logs received in the window of the last poll, which may be received again.
+		seen = make(map[tailedLog]struct{})
+	)
+
+	poll := func() error {
+		q := query
+		q.Start, q.End = polledUntil.Add(-t.tailIngestionDelay), time.Now().UTC()
+		if pageFull {
+			// Going back by the ingestion delay again would return the same page over and over.
+			q.Start = polledUntil
+		}
+
+		if q.Start.Before(resp.StartTime) {
+			q.Start = resp.StartTime
+		}
+
+		if !windowEnd.IsZero() && q.End.After(windowEnd) {
+			q.End = windowEnd
+		}
+
+		logs, err := cockpitClient.ListFunctionLogs(ctx, resourceName, q)
+		if err != nil {
+			return fmt.Errorf("listing function logs: %w", err)
+		}
+
+		polledUntil = q.End
+
+		pageFull = len(logs) == q.Limit
+		if pageFull {
+			// The window holds more logs: the next poll resumes after the last one.
+			polledUntil = logs[len(logs)-1].Timestamp
+		}
+
+		maps.DeleteFunc(seen, func(l tailedLog, _ struct{}) bool {
+			return l.timestamp < q.Start.UnixNano()
+		})
+
+		for _, log := range logs {
+			key := tailedLog{timestamp: log.Timestamp.UnixNano(), message: log.Message}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			onLog(log)
+
+			resp.Count++
+			resp.Logs = append(resp.Logs, log)
+		}
+
+		// Late logs can be older than the ones received before them.
+		slices.SortStableFunc(resp.Logs, func(a, b cockpit.Log) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+		resp.Logs = resp.Logs[max(0, len(resp.Logs)-tailSummaryLines):]
+
+		return nil
+	}
+
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+
+	deadline := time.NewTimer(duration)
+	defer deadline.Stop()
+
+	// Set once the duration is over, to poll the logs which are not available yet.
+	var ingested <-chan time.Time
+
+	// The logs received so far were already sent as notifications, but are still worth returning.
+	partial := func() (*mcp.CallToolResult, TailFunctionLogsResponse, error) {
+		resp.EndTime = time.Now().UTC()
+
+		return nil, resp, nil
+	}
+
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			return partial()
+		case <-ticker.C:
+		case <-deadline.C:
+			windowEnd = resp.StartTime.Add(duration)
+
+			if t.tailIngestionDelay > 0 {
+				ingested = time.After(t.tailIngestionDelay)
+			} else {
+				done = true
+			}
+		case <-ingested:
+			done = true
+		}
+
+		// The last poll goes on until the whole window is received.
+		for more := true; more; more = done && pageFull {
+			if err := poll(); err != nil {
+				if ctx.Err() != nil {
+					return partial()
+				}
+
+				return nil, TailFunctionLogsResponse{}, err
+			}
+		}
+	}
+
+	resp.EndTime = windowEnd
+
+	return nil, resp, nil
+}
+
+// tailedLog identifies a log received by a tail.
+type tailedLog struct {
+	timestamp int64
+	message   string
+}
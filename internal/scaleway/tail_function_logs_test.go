@@ -0,0 +1,226 @@
+package scaleway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockcockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_TailFunctionLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid duration", func(t *testing.T) {
+		t.Parallel()
+
+		tools := &Tools{}
+
+		_, _, err := tools.TailFunctionLogs(t.Context(), nil, TailFunctionLogsRequest{
+			FunctionName: fixed.SomeFunctionName,
+			Duration:     "1h",
+		})
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockCockpitClient := mockcockpit.NewMockClient(t)
+
+		now := time.Now().UTC()
+
+		mockCockpitClient.EXPECT().
+			ListFunctionLogs(mock.Anything, "my-function-xyz", mock.MatchedBy(func(q cockpit.LogQuery) bool {
+				return q.Direction == cockpit.DirectionForward && q.Limit == maxLogsLimit && q.Level == "error"
+			})).
+			Return([]cockpit.Log{
+				{Timestamp: now, Message: "timeout 1"},
+				{Timestamp: now.Add(time.Millisecond), Message: "timeout 2"},
+			}, nil).
+			Once()
+
+		tools := &Tools{
+			functionsAPI:     expectTailedFunction(t),
+			newCockpitClient: func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+		}
+
+		_, resp, err := tools.TailFunctionLogs(t.Context(), nil, TailFunctionLogsRequest{
+			FunctionName: fixed.SomeFunctionName,
+			Duration:     "10ms",
+			Level:        "error",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, resp.Count)
+		assert.Equal(t, []cockpit.Log{
+			{Timestamp: now, Message: "timeout 1"},
+			{Timestamp: now.Add(time.Millisecond), Message: "timeout 2"},
+		}, resp.Logs)
+	})
+
+	t.Run("late logs", func(t *testing.T) {
+		t.Parallel()
+
+		mockCockpitClient := mockcockpit.NewMockClient(t)
+
+		now := time.Now().UTC()
+		late := cockpit.Log{Timestamp: now.Add(time.Millisecond), Message: "late"}
+		onTime := cockpit.Log{Timestamp: now.Add(2 * time.Millisecond), Message: "on time"}
+
+		mockCockpitClient.EXPECT().
+			ListFunctionLogs(mock.Anything, "my-function-xyz", mock.Anything).
+			Return([]cockpit.Log{onTime}, nil).
+			Once()
+
+		// Polled again once the duration is over, over the whole window.
+		mockCockpitClient.EXPECT().
+			ListFunctionLogs(mock.Anything, "my-function-xyz", mock.MatchedBy(func(q cockpit.LogQuery) bool {
+				return q.End.Sub(q.Start) == 10*time.Millisecond
+			})).
+			Return([]cockpit.Log{late, onTime}, nil).
+			Once()
+
+		tools := &Tools{
+			functionsAPI:       expectTailedFunction(t),
+			newCockpitClient:   func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+			tailIngestionDelay: 50 * time.Millisecond,
+		}
+
+		_, resp, err := tools.TailFunctionLogs(t.Context(), nil, TailFunctionLogsRequest{
+			FunctionName: fixed.SomeFunctionName,
+			Duration:     "10ms",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, resp.Count, "the log received twice should only be counted once")
+		assert.Equal(t, []cockpit.Log{late, onTime}, resp.Logs)
+		assert.Equal(t, resp.StartTime.Add(10*time.Millisecond), resp.EndTime)
+	})
+
+	t.Run("more logs than a page", func(t *testing.T) {
+		t.Parallel()
+
+		mockCockpitClient := mockcockpit.NewMockClient(t)
+
+		now := time.Now().UTC()
+
+		page := make([]cockpit.Log, maxLogsLimit)
+		for i := range page {
+			page[i] = cockpit.Log{Timestamp: now.Add(time.Duration(i) * time.Microsecond), Message: "request"}
+		}
+
+		last := page[len(page)-1]
+		next := cockpit.Log{Timestamp: last.Timestamp.Add(time.Microsecond), Message: "request"}
+
+		mockCockpitClient.EXPECT().
+			ListFunctionLogs(mock.Anything, "my-function-xyz", mock.Anything).
+			Return(page, nil).
+			Once()
+
+		// The next poll resumes from the last log, without going back by the ingestion delay.
+		mockCockpitClient.EXPECT().
+			ListFunctionLogs(mock.Anything, "my-function-xyz", mock.MatchedBy(func(q cockpit.LogQuery) bool {
+				return q.Start.Equal(last.Timestamp)
+			})).
+			Return([]cockpit.Log{last, next}, nil).
+			Once()
+
+		tools := &Tools{
+			functionsAPI:       expectTailedFunction(t),
+			newCockpitClient:   func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+			tailIngestionDelay: 50 * time.Millisecond,
+		}
+
+		_, resp, err := tools.TailFunctionLogs(t.Context(), nil, TailFunctionLogsRequest{
+			FunctionName: fixed.SomeFunctionName,
+			Duration:     "10ms",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, maxLogsLimit+1, resp.Count)
+		assert.Equal(t, next, resp.Logs[len(resp.Logs)-1])
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		mockCockpitClient := mockcockpit.NewMockClient(t)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		log := cockpit.Log{Timestamp: time.Now().UTC(), Message: "Function started"}
+
+		mockCockpitClient.EXPECT().
+			ListFunctionLogs(mock.Anything, "my-function-xyz", mock.Anything).
+			Run(func(context.Context, string, cockpit.LogQuery) { cancel() }).
+			Return([]cockpit.Log{log}, nil).
+			Once()
+
+		tools := &Tools{
+			functionsAPI:       expectTailedFunction(t),
+			newCockpitClient:   func(string, scw.Region) cockpit.Client { return mockCockpitClient },
+			tailIngestionDelay: time.Minute,
+		}
+
+		_, resp, err := tools.TailFunctionLogs(ctx, nil, TailFunctionLogsRequest{
+			FunctionName: fixed.SomeFunctionName,
+			Duration:     "10ms",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, resp.Count)
+		assert.Equal(t, []cockpit.Log{log}, resp.Logs)
+	})
+}
+
+func expectTailedFunction(t *testing.T) *mockscaleway.MockFunctionAPI {
+	t.Helper()
+
+	mockFunctionsAPI := mockscaleway.NewMockFunctionAPI(t)
+
+	mockFunctionsAPI.EXPECT().ListFunctions(mock.Anything, mock.Anything).Return(
+		&function.ListFunctionsResponse{
+			Functions: []*function.Function{{
+				ID:          fixed.SomeFunctionID,
+				Name:        fixed.SomeFunctionName,
+				NamespaceID: fixed.SomeNamespaceID,
+				DomainName:  "my-function-xyz.functions.fr-par.scw.cloud",
+			}},
+		},
+		nil,
+	).Once()
+
+	mockFunctionsAPI.EXPECT().GetNamespace(&function.GetNamespaceRequest{
+		NamespaceID: fixed.SomeNamespaceID,
+	}, mock.Anything).Return(&function.Namespace{
+		ID:        fixed.SomeNamespaceID,
+		ProjectID: fixed.SomeProjectID,
+		Region:    fixed.SomeRegion,
+	}, nil).Once()
+
+	return mockFunctionsAPI
+}
+
+func TestLogsCursor_advance(t *testing.T) {
+	t.Parallel()
+
+	cursor := logsCursor{timestamp: fixed.SomeTimestampA, seen: 1}
+
+	// Another log at the same timestamp: both must be skipped by the next poll.
+	cursor = cursor.advance([]cockpit.Log{{Timestamp: fixed.SomeTimestampA}})
+	assert.Equal(t, logsCursor{timestamp: fixed.SomeTimestampA, seen: 2}, cursor)
+
+	cursor = cursor.advance(nil)
+	assert.Equal(t, logsCursor{timestamp: fixed.SomeTimestampA, seen: 2}, cursor)
+
+	cursor = cursor.advance([]cockpit.Log{{Timestamp: fixed.SomeTimestampA}, {Timestamp: fixed.SomeTimestampB}})
+	assert.Equal(t, logsCursor{timestamp: fixed.SomeTimestampB, seen: 1}, cursor)
+}
@@ -1,24 +1,47 @@
 package scaleway
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/xdg"
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/moby/moby/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+var ErrUnknownTool = errors.New("unknown tool")
+
 type Tools struct {
 	scwClient    *scw.Client
 	functionsAPI FunctionAPI
 
-	cockpitClient cockpit.Client
-	projectID     string
+	// newCockpitClient creates the Cockpit client of a project and a region. Clients are only
+	// created when the logs or the metrics of a function in this project and region are fetched.
+	newCockpitClient func(projectID string, region scw.Region) cockpit.Client
+	cockpitClientsMu sync.Mutex
+	cockpitClients   map[cockpitClientKey]cockpit.Client
+
+	// tailIngestionDelay is how late logs can be available in Cockpit. Tails poll the logs
+	// again over this delay, and keep polling for this long once over.
+	tailIngestionDelay time.Duration
+
+	// httpClient is used to call the deployed functions.
+	httpClient *http.Client
 
-	// Docker client is only used for the "add_dependency" tool, and since initialization
+	// deploymentHistory is nil when the state directory cannot be found.
+	deploymentHistory *DeploymentHistory
+
+	// Docker client is only used for the "add_dependency" and "run_function_locally" tools, and since initialization
 	// can fail on some systems (e.g. when Docker is not installed/running), we only
 	// initialize it when needed, and only once.
 	loadDockerAPIOnce sync.Once
@@ -83,40 +106,253 @@ type FunctionAPI interface {
 		*function.GetFunctionDownloadURLRequest,
 		...scw.RequestOption,
 	) (*function.DownloadURL, error)
+
+	ListCrons(*function.ListCronsRequest, ...scw.RequestOption) (*function.ListCronsResponse, error)
+	CreateCron(*function.CreateCronRequest, ...scw.RequestOption) (*function.Cron, error)
+	UpdateCron(*function.UpdateCronRequest, ...scw.RequestOption) (*function.Cron, error)
+	DeleteCron(*function.DeleteCronRequest, ...scw.RequestOption) (*function.Cron, error)
+
+	ListDomains(*function.ListDomainsRequest, ...scw.RequestOption) (*function.ListDomainsResponse, error)
+	CreateDomain(*function.CreateDomainRequest, ...scw.RequestOption) (*function.Domain, error)
+	WaitForDomain(*function.WaitForDomainRequest, ...scw.RequestOption) (*function.Domain, error)
+	DeleteDomain(*function.DeleteDomainRequest, ...scw.RequestOption) (*function.Domain, error)
+
+	GetToken(*function.GetTokenRequest, ...scw.RequestOption) (*function.Token, error)
+	ListTokens(*function.ListTokensRequest, ...scw.RequestOption) (*function.ListTokensResponse, error)
+	CreateToken(*function.CreateTokenRequest, ...scw.RequestOption) (*function.Token, error)
+	DeleteToken(*function.DeleteTokenRequest, ...scw.RequestOption) (*function.Token, error)
+
+	// Regions returns the regions where Serverless Functions are available.
+	Regions() []scw.Region
 }
 
 var _ FunctionAPI = (*function.API)(nil)
 
-func NewTools(scwClient *scw.Client, projectID string) *Tools {
+type cockpitClientKey struct {
+	projectID string
+	region    scw.Region
+}
+
+func NewTools(scwClient *scw.Client) *Tools {
+	var (
+		deploymentHistory *DeploymentHistory
+		cockpitTokens     cockpit.TokenStore
+	)
+
+	if stateDir, err := xdg.StateDir(); err == nil {
+		deploymentHistory = NewDeploymentHistory(filepath.Join(stateDir, "deployments"))
+		cockpitTokens = cockpit.NewFileTokenStore(filepath.Join(stateDir, "cockpit-tokens"))
+	}
+
 	return &Tools{
-		scwClient:     scwClient,
-		functionsAPI:  function.NewAPI(scwClient),
-		cockpitClient: cockpit.NewClient(scwClient, projectID),
-		projectID:     projectID,
+		scwClient:    scwClient,
+		functionsAPI: function.NewAPI(scwClient),
+		newCockpitClient: func(projectID string, region scw.Region) cockpit.Client {
+			return cockpit.NewClient(scwClient, projectID, region, cockpitTokens)
+		},
+		tailIngestionDelay: defaultTailIngestionDelay,
+		httpClient:         http.DefaultClient,
+		deploymentHistory:  deploymentHistory,
 	}
 }
 
-func (t *Tools) Register(s *mcp.Server) {
-	// Namespace tools
-	mcp.AddTool(s, createAndDeployFunctionNamespaceTool, t.CreateAndDeployFunctionNamespace)
-	mcp.AddTool(s, listFunctionNamespacesTool, t.ListFunctionNamespaces)
-	mcp.AddTool(s, deleteFunctionNamespaceTool, t.DeleteFunctionNamespace)
+// ToolFilter selects the tools registered on the server.
+type ToolFilter struct {
+	// ReadOnly only registers tools which do not modify Scaleway resources.
+	ReadOnly bool
+	// Enabled, when not empty, only registers the listed tools.
+	Enabled []string
+	// Disabled never registers the listed tools.
+	Disabled []string
+}
 
-	// Function tools
-	mcp.AddTool(s, listFunctionsTool, t.ListFunctions)
-	mcp.AddTool(s, listFunctionRuntimesTool, t.ListFunctionRuntimes)
+func (f ToolFilter) allows(tool *mcp.Tool) bool {
+	if f.ReadOnly && (tool.Annotations == nil || !tool.Annotations.ReadOnlyHint) {
+		return false
+	}
+
+	if len(f.Enabled) > 0 && !slices.Contains(f.Enabled, tool.Name) {
+		return false
+	}
+
+	return !slices.Contains(f.Disabled, tool.Name)
+}
 
-	mcp.AddTool(s, createAndDeployFunctionTool, t.CreateAndDeployFunction)
-	mcp.AddTool(s, updateFunctionTool, t.UpdateFunction)
+// Instructions describes the filter to the client, so that it knows why some tools are missing.
+func (f ToolFilter) Instructions() string {
+	var lines []string
 
-	mcp.AddTool(s, deleteFunctionTool, t.DeleteFunction)
-	mcp.AddTool(s, downloadFunctionTool, t.DownloadFunction)
+	if f.ReadOnly {
+		lines = append(lines, "This server is running in read-only mode: "+
+			"tools that create, update or delete Scaleway resources are not available.")
+	}
+
+	if len(f.Enabled) > 0 {
+		lines = append(lines, "Only the following tools are enabled: "+strings.Join(f.Enabled, ", ")+".")
+	}
+
+	if len(f.Disabled) > 0 {
+		lines = append(lines, "The following tools are disabled: "+strings.Join(f.Disabled, ", ")+".")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type toolRegistration struct {
+	tool         *mcp.Tool
+	add          func(s *mcp.Server)
+	inputSchema  func() (*jsonschema.Schema, error)
+	outputSchema func() (*jsonschema.Schema, error)
+}
+
+func newToolRegistration[In, Out any](
+	tool *mcp.Tool,
+	handler mcp.ToolHandlerFor[In, Out],
+) toolRegistration {
+	return toolRegistration{
+		tool: tool,
+		add:  func(s *mcp.Server) { mcp.AddTool(s, tool, withValidScope(handler)) },
+		// Same inference as mcp.AddTool.
+		inputSchema: func() (*jsonschema.Schema, error) {
+			return jsonschema.For[In](&jsonschema.ForOptions{})
+		},
+		outputSchema: func() (*jsonschema.Schema, error) {
+			return jsonschema.For[Out](&jsonschema.ForOptions{})
+		},
+	}
+}
+
+// InputSchemas returns the input schemas of all the tools, keyed by tool name.
+// It's used to find out which fields of the tool inputs must not be logged.
+func InputSchemas() (map[string]*jsonschema.Schema, error) {
+	return collectSchemas("input", func(r toolRegistration) (*jsonschema.Schema, error) {
+		return r.inputSchema()
+	})
+}
+
+// OutputSchemas returns the output schemas of all the tools, keyed by tool name.
+// Like InputSchemas, it's used to find out which fields of the tool results must not be logged.
+func OutputSchemas() (map[string]*jsonschema.Schema, error) {
+	return collectSchemas("output", func(r toolRegistration) (*jsonschema.Schema, error) {
+		return r.outputSchema()
+	})
+}
+
+func collectSchemas(
+	kind string,
+	schemaOf func(toolRegistration) (*jsonschema.Schema, error),
+) (map[string]*jsonschema.Schema, error) {
+	schemas := make(map[string]*jsonschema.Schema)
+
+	// The handlers are never called, so the zero value is fine.
+	for _, r := range (&Tools{}).toolRegistrations() {
+		schema, err := schemaOf(r)
+		if err != nil {
+			return nil, fmt.Errorf("inferring %s schema of tool %q: %w", kind, r.tool.Name, err)
+		}
+
+		schemas[r.tool.Name] = schema
+	}
+
+	return schemas, nil
+}
+
+func (t *Tools) toolRegistrations() []toolRegistration {
+	return []toolRegistration{
+		// Namespace tools
+		newToolRegistration(createAndDeployFunctionNamespaceTool, t.CreateAndDeployFunctionNamespace),
+		newToolRegistration(listFunctionNamespacesTool, t.ListFunctionNamespaces),
+		newToolRegistration(deleteFunctionNamespaceTool, t.DeleteFunctionNamespace),
+
+		// Function tools
+		newToolRegistration(listFunctionsTool, t.ListFunctions),
+		newToolRegistration(listFunctionRuntimesTool, t.ListFunctionRuntimes),
+
+		newToolRegistration(createAndDeployFunctionTool, t.CreateAndDeployFunction),
+		newToolRegistration(updateFunctionTool, t.UpdateFunction),
+
+		newToolRegistration(deleteFunctionTool, t.DeleteFunction),
+		newToolRegistration(downloadFunctionTool, t.DownloadFunction),
+		newToolRegistration(diffFunctionTool, t.DiffFunction),
+		newToolRegistration(invokeFunctionTool, t.InvokeFunction),
+
+		// Trigger tools
+		newToolRegistration(createCronTriggerTool, t.CreateCronTrigger),
+		newToolRegistration(listCronTriggersTool, t.ListCronTriggers),
+		newToolRegistration(updateCronTriggerTool, t.UpdateCronTrigger),
+		newToolRegistration(deleteCronTriggerTool, t.DeleteCronTrigger),
+
+		// Domain tools
+		newToolRegistration(attachFunctionDomainTool, t.AttachFunctionDomain),
+		newToolRegistration(listFunctionDomainsTool, t.ListFunctionDomains),
+		newToolRegistration(detachFunctionDomainTool, t.DetachFunctionDomain),
+
+		// Token tools
+		newToolRegistration(createFunctionTokenTool, t.CreateFunctionToken),
+		newToolRegistration(listFunctionTokensTool, t.ListFunctionTokens),
+		newToolRegistration(revokeFunctionTokenTool, t.RevokeFunctionToken),
+
+		// Deployment history tools
+		newToolRegistration(listFunctionDeploymentsTool, t.ListFunctionDeployments),
+		newToolRegistration(rollbackFunctionTool, t.RollbackFunction),
+
+		// Manifest tools
+		newToolRegistration(planManifestTool, t.PlanManifest),
+		newToolRegistration(applyManifestTool, t.ApplyManifest),
+
+		// Requires Cockpit access
+		newToolRegistration(fetchFunctionLogsTool, t.FetchFunctionLogs),
+		newToolRegistration(tailFunctionLogsTool, t.TailFunctionLogs),
+		newToolRegistration(fetchFunctionMetricsTool, t.FetchFunctionMetrics),
+		newToolRegistration(fetchFunctionBuildLogsTool, t.FetchFunctionBuildLogs),
+
+		// Docker tools
+		newToolRegistration(addDependencyTool, t.AddDependency),
+		newToolRegistration(runFunctionLocallyTool, t.RunFunctionLocally),
+	}
+}
+
+// Register adds the tools allowed by the filter to the server.
+func (t *Tools) Register(s *mcp.Server, filter ToolFilter) error {
+	registrations := t.toolRegistrations()
+
+	for _, name := range slices.Concat(filter.Enabled, filter.Disabled) {
+		known := slices.ContainsFunc(registrations, func(r toolRegistration) bool {
+			return r.tool.Name == name
+		})
+		if !known {
+			return fmt.Errorf("%w: %q", ErrUnknownTool, name)
+		}
+	}
+
+	for _, r := range registrations {
+		if filter.allows(r.tool) {
+			r.add(s)
+		}
+	}
+
+	return nil
+}
+
+// getCockpitClient returns the Cockpit client of the project and the region, creating it on first use.
+func (t *Tools) getCockpitClient(projectID string, region scw.Region) cockpit.Client {
+	t.cockpitClientsMu.Lock()
+	defer t.cockpitClientsMu.Unlock()
+
+	key := cockpitClientKey{projectID: projectID, region: region}
+
+	if c, ok := t.cockpitClients[key]; ok {
+		return c
+	}
+
+	if t.cockpitClients == nil {
+		t.cockpitClients = make(map[cockpitClientKey]cockpit.Client)
+	}
 
-	// Requires Cockpit access
-	mcp.AddTool(s, fetchFunctionLogsTool, t.FetchFunctionLogs)
+	c := t.newCockpitClient(projectID, region)
+	t.cockpitClients[key] = c
 
-	// Dependency tools
-	mcp.AddTool(s, addDependencyTool, t.AddDependency)
+	return c
 }
 
 //nolint:nonamedreturns // actually like it this way.
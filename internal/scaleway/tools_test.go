@@ -0,0 +1,149 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogredact"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools_Register(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		filter    ToolFilter
+		wantTools []string
+		wantError require.ErrorAssertionFunc
+	}{
+		{
+			name:   "read-only",
+			filter: ToolFilter{ReadOnly: true},
+			wantTools: []string{
+				"diff_function",
+				"download_function",
+				"fetch_function_build_logs",
+				"fetch_function_logs",
+				"fetch_function_metrics",
+				"list_cron_triggers",
+				"list_function_deployments",
+				"list_function_domains",
+				"list_function_namespaces",
+				"list_function_runtimes",
+				"list_function_tokens",
+				"list_functions",
+				"plan_manifest",
+				"tail_function_logs",
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "read-only with disabled tools",
+			filter: ToolFilter{
+				ReadOnly: true,
+				Disabled: []string{"download_function", "fetch_function_build_logs"},
+			},
+			wantTools: []string{
+				"diff_function",
+				"fetch_function_logs",
+				"fetch_function_metrics",
+				"list_cron_triggers",
+				"list_function_deployments",
+				"list_function_domains",
+				"list_function_namespaces",
+				"list_function_runtimes",
+				"list_function_tokens",
+				"list_functions",
+				"plan_manifest",
+				"tail_function_logs",
+			},
+			wantError: require.NoError,
+		},
+		{
+			name: "read-only takes precedence over enabled tools",
+			filter: ToolFilter{
+				ReadOnly: true,
+				Enabled:  []string{"list_functions", "delete_function"},
+			},
+			wantTools: []string{"list_functions"},
+			wantError: require.NoError,
+		},
+		{
+			name: "unknown tool",
+			filter: ToolFilter{
+				Disabled: []string{"rm_rf"},
+			},
+			wantError: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrUnknownTool)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+
+			err := (&Tools{}).Register(server, tc.filter)
+			tc.wantError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+			serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+			require.NoError(t, err)
+
+			t.Cleanup(func() { _ = serverSession.Close() })
+
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+
+			clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+			require.NoError(t, err)
+
+			t.Cleanup(func() { _ = clientSession.Close() })
+
+			resp, err := clientSession.ListTools(t.Context(), &mcp.ListToolsParams{})
+			require.NoError(t, err)
+
+			gotTools := make([]string, 0, len(resp.Tools))
+			for _, tool := range resp.Tools {
+				gotTools = append(gotTools, tool.Name)
+			}
+
+			assert.ElementsMatch(t, tc.wantTools, gotTools)
+		})
+	}
+}
+
+func TestInputSchemas(t *testing.T) {
+	t.Parallel()
+
+	schemas, err := InputSchemas()
+	require.NoError(t, err)
+
+	for _, name := range []string{createAndDeployFunctionTool.Name, updateFunctionTool.Name} {
+		require.Contains(t, schemas, name)
+		assert.Contains(t, schemas[name].Properties, "secret_environment_variables")
+	}
+
+	for name, schema := range schemas {
+		assert.Contains(t, schema.Properties, "project_id", name)
+		assert.Contains(t, schema.Properties, "region", name)
+	}
+}
+
+func TestOutputSchemas(t *testing.T) {
+	t.Parallel()
+
+	schemas, err := OutputSchemas()
+	require.NoError(t, err)
+
+	require.Contains(t, schemas, createFunctionTokenTool.Name)
+	assert.Contains(t, slogredact.PathsFromSchema(schemas[createFunctionTokenTool.Name]), "token")
+}
@@ -3,6 +3,8 @@ package scaleway
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,12 +18,22 @@ import (
 var updateFunctionTool = &mcp.Tool{
 	Name: "update_function",
 	Description: `Update the code or configuration of an existing Scaleway Function from a local directory.
-		This can be useful to fix any mistakes you've made in the code.`,
+		This can be useful to fix any mistakes you've made in the code.
+
+		- "environment_variables" replaces all the environment variables of the function.
+		- "secret_environment_variables" is merged with the existing secrets: only the provided keys are updated.
+		  To delete a secret, set its value to null.
+
+		- "privacy" is either "public" or "private", and "http_option" either "enabled" or "redirected" (HTTP to HTTPS).
+
+		If the code did not change, only the configuration is redeployed.`,
 }
 
 // We could embed function.CreateFunctionRequest but:
 // - It seems the LLM is much better with `function_name` than `function_id`.
 type UpdateFunctionRequest struct {
+	Scope
+
 	Directory    string `json:"directory"`
 	FunctionName string `json:"function_name"`
 
@@ -33,6 +45,12 @@ type UpdateFunctionRequest struct {
 	MinScale    *uint32   `json:"min_scale,omitempty"`
 	MaxScale    *uint32   `json:"max_scale,omitempty"`
 	MemoryLimit *uint32   `json:"memory_limit,omitempty"`
+	Privacy     *string   `json:"privacy,omitempty"`
+	HTTPOption  *string   `json:"http_option,omitempty"`
+
+	EnvironmentVariables *map[string]string `json:"environment_variables,omitempty"`
+	// A nil value deletes the secret.
+	SecretEnvironmentVariables map[string]*string `json:"secret_environment_variables,omitempty"`
 }
 
 //nolint:funlen
@@ -73,6 +91,16 @@ func (req UpdateFunctionRequest) ToSDK(
 		}
 	}
 
+	privacy, err := parsePrivacy(valueOrDefault(req.Privacy, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	httpOption, err := parseHTTPOption(valueOrDefault(req.HTTPOption, ""))
+	if err != nil {
+		return nil, err
+	}
+
 	var runtime function.FunctionRuntime
 
 	if newRuntime := req.Runtime; newRuntime != nil {
@@ -96,43 +124,92 @@ func (req UpdateFunctionRequest) ToSDK(
 	}
 
 	return &function.UpdateFunctionRequest{
-		FunctionID:  currentFunction.ID,
-		Runtime:     runtime,
-		Handler:     handler,
-		Timeout:     timeout,
-		Description: req.Description,
-		Tags:        tags,
-		MinScale:    req.MinScale,
-		MaxScale:    req.MaxScale,
-		MemoryLimit: req.MemoryLimit,
+		Region:                     currentFunction.Region,
+		FunctionID:                 currentFunction.ID,
+		Runtime:                    runtime,
+		Handler:                    handler,
+		Timeout:                    timeout,
+		Description:                req.Description,
+		Tags:                       tags,
+		MinScale:                   req.MinScale,
+		MaxScale:                   req.MaxScale,
+		MemoryLimit:                req.MemoryLimit,
+		EnvironmentVariables:       req.EnvironmentVariables,
+		SecretEnvironmentVariables: req.secretsToSDK(),
+		Privacy:                    privacy,
+		HTTPOption:                 httpOption,
 	}, nil
 }
 
-//nolint:funlen
+// secretsToSDK converts the secrets to update. The API keeps secrets that are not
+// provided, and deletes the ones provided without a value.
+func (req UpdateFunctionRequest) secretsToSDK() []*function.Secret {
+	if len(req.SecretEnvironmentVariables) == 0 {
+		return nil
+	}
+
+	secrets := make([]*function.Secret, 0, len(req.SecretEnvironmentVariables))
+
+	// Sorted for a deterministic request.
+	for _, k := range slices.Sorted(maps.Keys(req.SecretEnvironmentVariables)) {
+		secrets = append(secrets, &function.Secret{
+			Key:   k,
+			Value: req.SecretEnvironmentVariables[k],
+		})
+	}
+
+	return secrets
+}
+
 func (t *Tools) UpdateFunction(
 	ctx context.Context,
 	req *mcp.CallToolRequest,
 	in UpdateFunctionRequest,
-) (*mcp.CallToolResult, Function, error) {
-	logger := slogctx.FromContext(ctx)
-	progress := NewFunctionDeploymentProgress(in.FunctionName)
-
-	fun, err := getFunctionByName(ctx, t.functionsAPI, in.FunctionName)
+) (*mcp.CallToolResult, FunctionDeployment, error) {
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, in.Scope, in.FunctionName)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("getting function by name: %w", err)
+		return nil, FunctionDeployment{}, err
 	}
 
-	if err := checkResourceOwnership(fun.Tags); err != nil {
-		return nil, Function{}, err
+	deployment, err := t.updateFunction(ctx, req, fun, in)
+	if err != nil {
+		return nil, FunctionDeployment{}, err
 	}
 
+	return nil, deployment, nil
+}
+
+// updateFunction archives the directory of the request and deploys it to fun.
+func (t *Tools) updateFunction(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	fun *function.Function,
+	in UpdateFunctionRequest,
+) (FunctionDeployment, error) {
+	progress := NewFunctionDeploymentProgress(in.FunctionName)
+
 	progress.NotifyCodeArchiveCreation(ctx, req)
 
 	archive, err := NewCodeArchive(in.Directory)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("creating archive: %w", err)
+		return FunctionDeployment{}, fmt.Errorf("creating archive: %w", err)
 	}
 
+	return t.updateFunctionWithArchive(ctx, req, progress, fun, archive, in)
+}
+
+// updateFunctionWithArchive uploads the archive if its digest differs from the deployed one,
+// then applies the update and waits for the function to be ready.
+// req may be nil when the update does not come from a tool call (e.g. in watch mode).
+func (t *Tools) updateFunctionWithArchive(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	progress *FunctionDeploymentProgress,
+	fun *function.Function,
+	archive *CodeArchive,
+	in UpdateFunctionRequest,
+) (FunctionDeployment, error) {
+	logger := slogctx.FromContext(ctx)
 	shouldUpload := true
 
 	digest, found := getCodeArchiveDigestFromTags(fun.Tags)
@@ -148,40 +225,53 @@ func (t *Tools) UpdateFunction(
 	if shouldUpload {
 		presignedURLResp, err := t.functionsAPI.GetFunctionUploadURL(
 			&function.GetFunctionUploadURLRequest{
+				Region:        fun.Region,
 				FunctionID:    fun.ID,
 				ContentLength: archive.Size,
 			},
 			scw.WithContext(ctx),
 		)
 		if err != nil {
-			return nil, Function{}, fmt.Errorf("getting presigned URL: %w", err)
+			return FunctionDeployment{}, fmt.Errorf("getting presigned URL: %w", err)
 		}
 
 		progress.NotifyCodeUploading(ctx, req)
 
 		if err := archive.Upload(ctx, presignedURLResp.URL); err != nil {
-			return nil, Function{}, fmt.Errorf("uploading archive: %w", err)
+			return FunctionDeployment{}, fmt.Errorf("uploading archive: %w", err)
 		}
 	}
 
 	updateReq, err := in.ToSDK(fun, archive.Digest)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("converting to SDK request: %w", err)
+		return FunctionDeployment{}, fmt.Errorf("converting to SDK request: %w", err)
 	}
 
+	deploymentStartedAt := time.Now()
+
 	fun, err = t.functionsAPI.UpdateFunction(updateReq, scw.WithContext(ctx))
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("updating function: %w", err)
+		return FunctionDeployment{}, fmt.Errorf("updating function: %w", err)
 	}
 
 	if shouldUpload {
 		progress.NotifyBuildStarted(ctx, req)
 	}
 
-	fun, err = waitForFunction(ctx, t.functionsAPI, fun.ID, progress.GetFunctionBuildCB(ctx, req))
+	buildCB := progress.GetFunctionBuildCB(ctx, req)
+	if progress.onBuildLog != nil {
+		buildCB = t.followBuildLogs(ctx, fun, deploymentStartedAt, progress.onBuildLog, buildCB)
+	}
+
+	fun, err = waitForFunction(ctx, t.functionsAPI, fun.Region, fun.ID, buildCB)
 	if err != nil {
-		return nil, Function{}, fmt.Errorf("waiting for function to be ready: %w", err)
+		return FunctionDeployment{}, fmt.Errorf("waiting for function to be ready: %w", err)
 	}
 
-	return nil, NewFunctionFromSDK(fun), nil
+	t.recordDeployment(ctx, fun, archive)
+
+	return FunctionDeployment{
+		Function:  t.newFunctionWithHostnames(ctx, fun),
+		BuildLogs: t.getBuildLogsOnError(ctx, fun, deploymentStartedAt),
+	}, nil
 }
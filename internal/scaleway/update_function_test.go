@@ -119,6 +119,70 @@ func TestUpdateFunctionRequest_ToSDK(t *testing.T) {
 			},
 			wantError: assert.NoError,
 		},
+		{
+			name: "set environment variables and merge secrets",
+			in: UpdateFunctionRequest{
+				EnvironmentVariables: &map[string]string{"LOG_LEVEL": "debug"},
+				SecretEnvironmentVariables: map[string]*string{
+					"API_KEY":   scw.StringPtr("rotated"),
+					"TO_DELETE": nil,
+				},
+			},
+			givenFunction: &function.Function{
+				ID:   "func-123",
+				Name: "my-function",
+				Tags: []string{
+					constants.TagCreatedByScalewayMCP,
+					constants.TagCodeArchiveDigestPrefix + fixed.SomeCodeArchiveDigest,
+				},
+			},
+			givenDigest: fixed.SomeCodeArchiveDigest,
+			wantSDKReq: &function.UpdateFunctionRequest{
+				FunctionID:           "func-123",
+				EnvironmentVariables: &map[string]string{"LOG_LEVEL": "debug"},
+				SecretEnvironmentVariables: []*function.Secret{
+					{Key: "API_KEY", Value: scw.StringPtr("rotated")},
+					{Key: "TO_DELETE", Value: nil},
+				},
+			},
+			wantError: assert.NoError,
+		},
+		{
+			name: "make function private",
+			in: UpdateFunctionRequest{
+				Privacy:    scw.StringPtr("Private"),
+				HTTPOption: scw.StringPtr("redirected"),
+			},
+			givenFunction: &function.Function{
+				ID:   "func-123",
+				Name: "my-function",
+				Tags: []string{
+					constants.TagCreatedByScalewayMCP,
+					constants.TagCodeArchiveDigestPrefix + fixed.SomeCodeArchiveDigest,
+				},
+			},
+			givenDigest: fixed.SomeCodeArchiveDigest,
+			wantSDKReq: &function.UpdateFunctionRequest{
+				FunctionID: "func-123",
+				Privacy:    function.FunctionPrivacyPrivate,
+				HTTPOption: function.FunctionHTTPOptionRedirected,
+			},
+			wantError: assert.NoError,
+		},
+		{
+			name: "invalid privacy",
+			in: UpdateFunctionRequest{
+				Privacy: scw.StringPtr("internal"),
+			},
+			givenFunction: &function.Function{
+				ID:   "func-123",
+				Name: "my-function",
+			},
+			givenDigest: fixed.SomeCodeArchiveDigest,
+			wantError: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidValue)
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -0,0 +1,251 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/scaleway/cockpit"
+	"github.com/cyclimse/mcp-scaleway-functions/pkg/slogctx"
+	"github.com/fsnotify/fsnotify"
+)
+
+type WatchFunctionOptions struct {
+	// Scope selects the project and region of the function, defaulting to the ones of the client.
+	Scope        Scope
+	Directory    string
+	FunctionName string
+	// Debounce is how long the files must stop changing before the function is redeployed.
+	Debounce time.Duration
+	// OnDeployment is called after each deployment, successful or not.
+	OnDeployment func(FunctionDeployment, error)
+	// OnProgress is called with the progress messages of each deployment. Optional.
+	OnProgress func(message string)
+	// OnBuildLog is called with the build logs of each deployment as they arrive. Optional.
+	OnBuildLog func(log cockpit.Log)
+}
+
+// WatchFunction redeploys the function every time the code in the directory changes, until
+// the context is done. It uses the same update path as the "update_function" tool.
+func (t *Tools) WatchFunction(ctx context.Context, opts WatchFunctionOptions) error {
+	if err := opts.Scope.validateScope(); err != nil {
+		return err
+	}
+
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, opts.Scope, opts.FunctionName)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := newDirectoryWatcher(opts.Directory)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	lastDigest, _ := getCodeArchiveDigestFromTags(fun.Tags)
+
+	redeploy := func() {
+		lastDigest = t.redeployFunctionOnChange(ctx, opts, lastDigest)
+	}
+
+	// The local code may have changed since the last deployment.
+	redeploy()
+
+	return watcher.run(ctx, opts.Debounce, redeploy)
+}
+
+// redeployFunctionOnChange updates the function if the digest of the code changed,
+// and returns the digest of the code now deployed.
+func (t *Tools) redeployFunctionOnChange(
+	ctx context.Context,
+	opts WatchFunctionOptions,
+	lastDigest string,
+) string {
+	logger := slogctx.FromContext(ctx).With("function_name", opts.FunctionName)
+	progress := NewFunctionDeploymentProgress(opts.FunctionName)
+	progress.output = opts.OnProgress
+	progress.onBuildLog = opts.OnBuildLog
+
+	progress.NotifyCodeArchiveCreation(ctx, nil)
+
+	archive, err := NewCodeArchive(opts.Directory)
+	if err != nil {
+		opts.OnDeployment(FunctionDeployment{}, fmt.Errorf("creating archive: %w", err))
+
+		return lastDigest
+	}
+
+	defer func() {
+		_ = os.Remove(archive.Path)
+	}()
+
+	if archive.CompareDigest(lastDigest) {
+		logger.InfoContext(ctx, "Code did not change, skipping deployment", "digest", lastDigest)
+
+		return lastDigest
+	}
+
+	// The function is fetched again, as it may have been updated in the meantime.
+	fun, err := getOwnedFunctionByName(ctx, t.functionsAPI, opts.Scope, opts.FunctionName)
+	if err != nil {
+		opts.OnDeployment(FunctionDeployment{}, err)
+
+		return lastDigest
+	}
+
+	deployment, err := t.updateFunctionWithArchive(ctx, nil, progress, fun, archive, UpdateFunctionRequest{
+		Scope:        opts.Scope,
+		Directory:    opts.Directory,
+		FunctionName: opts.FunctionName,
+	})
+	opts.OnDeployment(deployment, err)
+
+	if err != nil {
+		return lastDigest
+	}
+
+	// Even if the build failed, there is no point in deploying the same code again.
+	return archive.Digest
+}
+
+// directoryWatcher watches the files of a function directory, except the ones
+// left out of the code archives.
+type directoryWatcher struct {
+	root    string
+	watcher *fsnotify.Watcher
+	ignore  *ignoreMatcher
+}
+
+func newDirectoryWatcher(root string) (*directoryWatcher, error) {
+	ignore, err := loadIgnoreMatcher(root)
+	if err != nil {
+		return nil, fmt.Errorf("loading ignore files: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating file watcher: %w", err)
+	}
+
+	w := &directoryWatcher{
+		root:    root,
+		watcher: watcher,
+		ignore:  ignore,
+	}
+
+	if err := w.addDirectories(root); err != nil {
+		_ = watcher.Close()
+
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *directoryWatcher) Close() error {
+	return w.watcher.Close()
+}
+
+// addDirectories watches the directory and its subdirectories, as fsnotify is not recursive.
+func (w *directoryWatcher) addDirectories(dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(w.root, path)
+		if err != nil {
+			return fmt.Errorf("getting relative path: %w", err)
+		}
+
+		if relativePath != "." && w.ignore.isIgnored(filepath.ToSlash(relativePath), true) {
+			return filepath.SkipDir
+		}
+
+		return w.watcher.Add(path)
+	})
+	// The directory may already be gone.
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("watching directory %q: %w", dir, err)
+	}
+
+	return nil
+}
+
+// run calls onChange once the files stopped changing for the debounce duration,
+// until the context is done.
+func (w *directoryWatcher) run(ctx context.Context, debounce time.Duration, onChange func()) error {
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			return fmt.Errorf("watching files: %w", err)
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			relevant, err := w.handleEvent(event)
+			if err != nil {
+				return err
+			}
+
+			if relevant {
+				timer.Reset(debounce)
+			}
+		case <-timer.C:
+			onChange()
+		}
+	}
+}
+
+// handleEvent keeps the watched directories and the ignore rules up to date,
+// and reports whether the event may change the code archive.
+func (w *directoryWatcher) handleEvent(event fsnotify.Event) (bool, error) {
+	relativePath, err := filepath.Rel(w.root, event.Name)
+	if err != nil {
+		return false, fmt.Errorf("getting relative path: %w", err)
+	}
+
+	if filepath.Base(relativePath) == gitIgnoreFile || relativePath == scwIgnoreFile {
+		if w.ignore, err = loadIgnoreMatcher(w.root); err != nil {
+			return false, fmt.Errorf("loading ignore files: %w", err)
+		}
+
+		// Some directories may not be ignored anymore.
+		return true, w.addDirectories(w.root)
+	}
+
+	info, err := os.Lstat(event.Name)
+	isDir := err == nil && info.IsDir()
+
+	if w.ignore.isIgnored(filepath.ToSlash(relativePath), isDir) {
+		return false, nil
+	}
+
+	if isDir && event.Has(fsnotify.Create) {
+		return true, w.addDirectories(event.Name)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package scaleway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/fixed"
+	"github.com/cyclimse/mcp-scaleway-functions/internal/testing/mockscaleway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryWatcher(t *testing.T) {
+	t.Parallel()
+
+	const debounce = 50 * time.Millisecond
+
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "__pycache__"), 0o750))
+
+	watcher, err := newDirectoryWatcher(root)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = watcher.Close()
+	})
+
+	changes := make(chan struct{}, 10)
+
+	go func() {
+		_ = watcher.run(t.Context(), debounce, func() {
+			changes <- struct{}{}
+		})
+	}()
+
+	writeFile := func(name string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(name), 0o600))
+	}
+
+	assertChanges := func(t *testing.T, want int) {
+		t.Helper()
+
+		got := 0
+		timeout := time.After(10 * debounce)
+
+		for {
+			select {
+			case <-changes:
+				got++
+			case <-timeout:
+				assert.Equal(t, want, got)
+
+				return
+			}
+		}
+	}
+
+	// Files which are not part of the code archive are ignored.
+	writeFile("__pycache__/handler.cpython-313.pyc")
+	writeFile(".env")
+	assertChanges(t, 0)
+
+	// Bursts of changes are debounced.
+	writeFile("handler.py")
+	writeFile("utils.py")
+	writeFile("handler.py")
+	assertChanges(t, 1)
+
+	// New directories are watched too.
+	require.NoError(t, os.Mkdir(filepath.Join(root, "lib"), 0o750))
+	assertChanges(t, 1)
+
+	writeFile("lib/helpers.py")
+	assertChanges(t, 1)
+}
+
+func TestTools_RedeployFunctionOnChange_SkipsUnchangedCode(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "handler.py"), []byte("print('hello')"), 0o600))
+
+	digest := archiveDigest(t, root)
+
+	// No calls to the API are expected.
+	tools := &Tools{functionsAPI: mockscaleway.NewMockFunctionAPI(t)}
+
+	got := tools.redeployFunctionOnChange(t.Context(), WatchFunctionOptions{
+		Directory:    root,
+		FunctionName: fixed.SomeFunctionName,
+		OnDeployment: func(FunctionDeployment, error) {
+			assert.Fail(t, "the function should not be redeployed")
+		},
+	}, digest)
+
+	assert.Equal(t, digest, got)
+}
+
+func TestFunctionDeploymentProgress_output(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+
+	progress := NewFunctionDeploymentProgress(fixed.SomeFunctionName)
+	progress.output = func(message string) { got = append(got, message) }
+
+	// Outside of a tool call, the messages are sent to the output.
+	progress.NotifyCodeArchiveCreation(t.Context(), nil)
+	progress.NotifyCodeUploading(t.Context(), nil)
+
+	assert.Equal(t, []string{"📂 Creating code archive", "📤 Uploading code..."}, got)
+}
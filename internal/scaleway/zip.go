@@ -7,22 +7,29 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
 	"net/http"
 	"os"
 	"path/filepath"
 )
 
-const avoidZipBombMaxSize = 1024 * 1024 * 100 // 100MB
+const (
+	avoidZipBombMaxSize    = 1024 * 1024 * 100 // 100MB
+	maxSymlinkTargetLength = 4096
+)
 
 var (
 	ErrUploadingCodeArchive   = errors.New("uploading code archive")
 	ErrDownloadingCodeArchive = errors.New("downloading code archive")
+	ErrSymlinkOutsideRoot     = errors.New("symlink points outside of the function directory")
 )
 
 type CodeArchive struct {
-	Path   string
-	Size   uint64
+	Path string
+	Size uint64
+	// Digest identifies the content of the archive (see contentManifest). Unlike a hash
+	// of the zip file, it does not change when only file timestamps or the zip metadata do.
 	Digest string
 }
 
@@ -36,26 +43,16 @@ func NewCodeArchive(from string) (*CodeArchive, error) {
 		_ = zipFile.Close()
 	}()
 
-	err = zipDirectory(zipFile, from)
+	digest, err := zipDirectory(zipFile, from)
 	if err != nil {
 		return nil, fmt.Errorf("zipping directory: %w", err)
 	}
 
-	_, err = zipFile.Seek(0, 0)
-	if err != nil {
-		return nil, fmt.Errorf("seeking zip file: %w", err)
-	}
-
 	stat, err := zipFile.Stat()
 	if err != nil {
 		return nil, fmt.Errorf("getting zip file stat: %w", err)
 	}
 
-	digest, err := computeFileDigest(zipFile)
-	if err != nil {
-		return nil, fmt.Errorf("computing zip file digest: %w", err)
-	}
-
 	return &CodeArchive{
 		Path:   zipFile.Name(),
 		Size:   safeConvertInt64ToUint64(stat.Size()),
@@ -145,27 +142,27 @@ func DownloadAndExtractCodeArchive(ctx context.Context, url, toDir string) error
 	return nil
 }
 
-func zipDirectory(zipFile *os.File, pathToDir string) error {
+// zipDirectory writes the content of the directory to the zip file, and returns the content digest.
+func zipDirectory(zipFile *os.File, pathToDir string) (string, error) {
 	zipWriter := zip.NewWriter(zipFile)
 
 	defer func() {
 		_ = zipWriter.Close()
 	}()
 
-	walker := func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return fmt.Errorf("walking directory: %w", err)
-		}
+	manifest := &contentManifest{}
 
-		if info.IsDir() {
-			return nil
+	err := walkCodeArchiveFiles(pathToDir, func(relativePath string, info os.FileInfo) error {
+		// Modification times are left out on purpose, so that touching a file
+		// does not change the archive.
+		header := &zip.FileHeader{
+			Name:   filepath.ToSlash(relativePath),
+			Method: zip.Deflate,
 		}
+		header.SetMode(info.Mode())
 
-		// Very important to use filepath.Rel() here to avoid zipping the full path.
-		// Otherwise, we end up with a zip file containing the full path to the file like: `workspaces/e2e/assets/...`.
-		relativePath, err := filepath.Rel(pathToDir, path)
-		if err != nil {
-			return fmt.Errorf("getting relative path: %w", err)
+		if info.Mode()&os.ModeSymlink != 0 {
+			return zipSymlink(zipWriter, manifest, header, pathToDir, relativePath)
 		}
 
 		// We use os.OpenInRoot to avoid local inclusion vulnerabilities.
@@ -178,34 +175,139 @@ func zipDirectory(zipFile *os.File, pathToDir string) error {
 			_ = file.Close()
 		}()
 
-		f, err := zipWriter.Create(relativePath)
+		f, err := zipWriter.CreateHeader(header)
 		if err != nil {
 			return fmt.Errorf("creating file in zip: %w", err)
 		}
 
-		_, err = io.Copy(f, file)
+		contentHash := sha256.New()
+
+		_, err = io.Copy(io.MultiWriter(f, contentHash), file)
 		if err != nil {
 			return fmt.Errorf("copying file to zip: %w", err)
 		}
 
+		manifest.add(header.Name, info.Mode(), contentHash)
+
 		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
-	err := filepath.Walk(pathToDir, walker)
+	if err := zipWriter.Close(); err != nil {
+		return "", fmt.Errorf("closing zip writer: %w", err)
+	}
+
+	return manifest.digest(), nil
+}
+
+// walkCodeArchiveFiles calls fn for every file of the directory which belongs in the code archive,
+// with its path relative to the directory.
+func walkCodeArchiveFiles(pathToDir string, fn func(relativePath string, info os.FileInfo) error) error {
+	ignore, err := loadIgnoreMatcher(pathToDir)
 	if err != nil {
+		return fmt.Errorf("loading ignore files: %w", err)
+	}
+
+	walker := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("walking directory: %w", err)
+		}
+
+		// Very important to use filepath.Rel() here to avoid zipping the full path.
+		// Otherwise, we end up with a zip file containing the full path to the file like: `workspaces/e2e/assets/...`.
+		relativePath, err := filepath.Rel(pathToDir, path)
+		if err != nil {
+			return fmt.Errorf("getting relative path: %w", err)
+		}
+
+		if info.IsDir() {
+			if relativePath != "." && ignore.isIgnored(filepath.ToSlash(relativePath), true) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if ignore.isIgnored(filepath.ToSlash(relativePath), false) {
+			return nil
+		}
+
+		return fn(relativePath, info)
+	}
+
+	if err := filepath.Walk(pathToDir, walker); err != nil {
 		return fmt.Errorf("walking directory %q: %w", pathToDir, err)
 	}
 
 	return nil
 }
 
-func computeFileDigest(file *os.File) (string, error) {
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", fmt.Errorf("computing file digest: %w", err)
+// zipSymlink stores a symlink as is, with its target as content (like the zip CLI does).
+func zipSymlink(
+	zipWriter *zip.Writer,
+	manifest *contentManifest,
+	header *zip.FileHeader,
+	root, relativePath string,
+) error {
+	target, err := os.Readlink(filepath.Join(root, relativePath))
+	if err != nil {
+		return fmt.Errorf("reading symlink: %w", err)
+	}
+
+	if err := checkSymlinkTarget(relativePath, target); err != nil {
+		return err
+	}
+
+	header.Method = zip.Store
+
+	f, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("creating symlink in zip: %w", err)
+	}
+
+	contentHash := sha256.New()
+
+	_, err = io.WriteString(io.MultiWriter(f, contentHash), filepath.ToSlash(target))
+	if err != nil {
+		return fmt.Errorf("writing symlink to zip: %w", err)
 	}
 
-	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+	manifest.add(header.Name, header.Mode(), contentHash)
+
+	return nil
+}
+
+// checkSymlinkTarget makes sure that a symlink points inside the root directory, otherwise
+// it could be used to read or write files outside of the function directory.
+func checkSymlinkTarget(linkPath, target string) error {
+	if filepath.IsAbs(target) {
+		return fmt.Errorf("%w: %q points to absolute path %q", ErrSymlinkOutsideRoot, linkPath, target)
+	}
+
+	if !filepath.IsLocal(filepath.Join(filepath.Dir(linkPath), target)) {
+		return fmt.Errorf("%w: %q points to %q", ErrSymlinkOutsideRoot, linkPath, target)
+	}
+
+	return nil
+}
+
+// checkSymlinkParents makes sure that no parent directory of a symlink is a symlink itself:
+// its target would then be relative to another directory than the one checked.
+func checkSymlinkParents(root *os.Root, linkPath string) error {
+	for dir := filepath.Dir(linkPath); dir != "."; dir = filepath.Dir(dir) {
+		info, err := root.Lstat(dir)
+		if err != nil {
+			return fmt.Errorf("checking symlink parent directory: %w", err)
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%w: %q is inside symlinked directory %q", ErrSymlinkOutsideRoot, linkPath, dir)
+		}
+	}
+
+	return nil
 }
 
 //nolint:revive,funlen
@@ -242,37 +344,97 @@ func unzipDirectory(zipPath, toDir string) error {
 			return fmt.Errorf("creating directory for file: %w", err)
 		}
 
-		// We use os.OpenInRoot to avoid local inclusion vulnerabilities.
-		outFile, err := root.Open(file.Name)
-
-		if os.IsNotExist(err) {
-			outFile, err = root.Create(file.Name)
-			if err != nil {
-				return fmt.Errorf("creating file: %w", err)
+		if file.Mode()&os.ModeSymlink != 0 {
+			if err := unzipSymlink(root, file); err != nil {
+				return err
 			}
-		}
 
-		if err != nil {
-			return fmt.Errorf("opening file: %w", err)
+			continue
 		}
 
-		rc, err := file.Open()
-		if err != nil {
-			return fmt.Errorf("opening zipped file: %w", err)
+		if err := unzipFile(root, file); err != nil {
+			return err
 		}
+	}
 
-		_, err = io.CopyN(outFile, rc, avoidZipBombMaxSize+1)
-		if err != nil && !errors.Is(err, io.EOF) {
-			return fmt.Errorf("copying zipped file to disk: %w", err)
-		}
+	return nil
+}
 
-		if err := outFile.Close(); err != nil {
-			return fmt.Errorf("closing output file: %w", err)
-		}
+func unzipFile(root *os.Root, file *zip.File) error {
+	perm := file.Mode().Perm()
+	if perm == 0 {
+		// Archives created by other tools may not carry Unix permissions.
+		perm = 0o644
+	}
 
-		if err := rc.Close(); err != nil {
-			return fmt.Errorf("closing zipped file: %w", err)
-		}
+	// We use os.Root to avoid local inclusion vulnerabilities.
+	outFile, err := root.OpenFile(file.Name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+
+	defer func() {
+		_ = outFile.Close()
+	}()
+
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("opening zipped file: %w", err)
+	}
+
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	_, err = io.CopyN(outFile, rc, avoidZipBombMaxSize+1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("copying zipped file to disk: %w", err)
+	}
+
+	// The permissions passed to OpenFile are only used for new files, and are subject to the umask.
+	if err := root.Chmod(file.Name, perm); err != nil {
+		return fmt.Errorf("setting file permissions: %w", err)
+	}
+
+	if err := outFile.Close(); err != nil {
+		return fmt.Errorf("closing output file: %w", err)
+	}
+
+	return nil
+}
+
+func unzipSymlink(root *os.Root, file *zip.File) error {
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("opening zipped symlink: %w", err)
+	}
+
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	target, err := io.ReadAll(io.LimitReader(rc, maxSymlinkTargetLength))
+	if err != nil {
+		return fmt.Errorf("reading zipped symlink: %w", err)
+	}
+
+	name := filepath.FromSlash(file.Name)
+	targetPath := filepath.FromSlash(string(target))
+
+	if err := checkSymlinkTarget(name, targetPath); err != nil {
+		return err
+	}
+
+	if err := checkSymlinkParents(root, name); err != nil {
+		return err
+	}
+
+	if err := root.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing existing file: %w", err)
+	}
+
+	if err := root.Symlink(targetPath, name); err != nil {
+		return fmt.Errorf("creating symlink: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,110 @@
+package scaleway
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeArchive_PreservesModesAndSymlinks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "handler.py"), []byte("print('hello')"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "run.sh"), []byte("#!/bin/sh\n"), 0o755))
+	require.NoError(t, os.Symlink(filepath.Join("bin", "run.sh"), filepath.Join(dir, "run")))
+
+	archive, err := NewCodeArchive(dir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.Remove(archive.Path) })
+
+	out := t.TempDir()
+
+	// Pre-existing files are overwritten.
+	require.NoError(t, os.WriteFile(filepath.Join(out, "handler.py"), []byte("print('a much longer old version')"), 0o600))
+
+	require.NoError(t, unzipDirectory(archive.Path, out))
+
+	content, err := os.ReadFile(filepath.Join(out, "handler.py"))
+	require.NoError(t, err)
+	assert.Equal(t, "print('hello')", string(content))
+
+	info, err := os.Stat(filepath.Join(out, "bin", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	target, err := os.Readlink(filepath.Join(out, "run"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("bin", "run.sh"), target)
+}
+
+func TestNewCodeArchive_RejectsSymlinksOutsideRoot(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		target string
+	}{
+		{name: "absolute", target: "/etc/passwd"},
+		{name: "relative", target: filepath.Join("..", "..", "secrets.txt")},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "lib"), 0o750))
+			require.NoError(t, os.Symlink(tc.target, filepath.Join(dir, "lib", "link")))
+
+			_, err := NewCodeArchive(dir)
+			require.ErrorIs(t, err, ErrSymlinkOutsideRoot)
+		})
+	}
+}
+
+func TestUnzipDirectory_RejectsSymlinksThroughSymlinkedDirectories(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+
+	// "sub/l" looks like it points to "x" at the root, but "sub" is the root itself.
+	zipWriter := zip.NewWriter(f)
+
+	for _, link := range []struct{ name, target string }{
+		{name: "sub", target: "."},
+		{name: "sub/l", target: "../x"},
+	} {
+		header := &zip.FileHeader{Name: link.name, Method: zip.Store}
+		header.SetMode(os.ModeSymlink | 0o777)
+
+		w, err := zipWriter.CreateHeader(header)
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(link.target))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, f.Close())
+
+	out := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, os.Mkdir(out, 0o750))
+
+	err = unzipDirectory(archivePath, out)
+	require.ErrorIs(t, err, ErrSymlinkOutsideRoot)
+
+	_, err = os.Lstat(filepath.Join(out, "l"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
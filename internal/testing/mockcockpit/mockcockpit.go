@@ -120,8 +120,8 @@ func (_c *MockClient_ListFunctionBuildLogs_Call) RunAndReturn(run func(ctx conte
 }
 
 // ListFunctionLogs provides a mock function for the type MockClient
-func (_mock *MockClient) ListFunctionLogs(ctx context.Context, resourceName string, start time.Time, end time.Time) ([]cockpit.Log, error) {
-	ret := _mock.Called(ctx, resourceName, start, end)
+func (_mock *MockClient) ListFunctionLogs(ctx context.Context, resourceName string, query cockpit.LogQuery) ([]cockpit.Log, error) {
+	ret := _mock.Called(ctx, resourceName, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListFunctionLogs")
@@ -129,18 +129,18 @@ func (_mock *MockClient) ListFunctionLogs(ctx context.Context, resourceName stri
 
 	var r0 []cockpit.Log
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]cockpit.Log, error)); ok {
-		return returnFunc(ctx, resourceName, start, end)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, cockpit.LogQuery) ([]cockpit.Log, error)); ok {
+		return returnFunc(ctx, resourceName, query)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []cockpit.Log); ok {
-		r0 = returnFunc(ctx, resourceName, start, end)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, cockpit.LogQuery) []cockpit.Log); ok {
+		r0 = returnFunc(ctx, resourceName, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]cockpit.Log)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
-		r1 = returnFunc(ctx, resourceName, start, end)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, cockpit.LogQuery) error); ok {
+		r1 = returnFunc(ctx, resourceName, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -155,13 +155,12 @@ type MockClient_ListFunctionLogs_Call struct {
 // ListFunctionLogs is a helper method to define mock.On call
 //   - ctx context.Context
 //   - resourceName string
-//   - start time.Time
-//   - end time.Time
-func (_e *MockClient_Expecter) ListFunctionLogs(ctx interface{}, resourceName interface{}, start interface{}, end interface{}) *MockClient_ListFunctionLogs_Call {
-	return &MockClient_ListFunctionLogs_Call{Call: _e.mock.On("ListFunctionLogs", ctx, resourceName, start, end)}
+//   - query cockpit.LogQuery
+func (_e *MockClient_Expecter) ListFunctionLogs(ctx interface{}, resourceName interface{}, query interface{}) *MockClient_ListFunctionLogs_Call {
+	return &MockClient_ListFunctionLogs_Call{Call: _e.mock.On("ListFunctionLogs", ctx, resourceName, query)}
 }
 
-func (_c *MockClient_ListFunctionLogs_Call) Run(run func(ctx context.Context, resourceName string, start time.Time, end time.Time)) *MockClient_ListFunctionLogs_Call {
+func (_c *MockClient_ListFunctionLogs_Call) Run(run func(ctx context.Context, resourceName string, query cockpit.LogQuery)) *MockClient_ListFunctionLogs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -171,19 +170,14 @@ func (_c *MockClient_ListFunctionLogs_Call) Run(run func(ctx context.Context, re
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 time.Time
+		var arg2 cockpit.LogQuery
 		if args[2] != nil {
-			arg2 = args[2].(time.Time)
-		}
-		var arg3 time.Time
-		if args[3] != nil {
-			arg3 = args[3].(time.Time)
+			arg2 = args[2].(cockpit.LogQuery)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
@@ -194,7 +188,81 @@ func (_c *MockClient_ListFunctionLogs_Call) Return(logs []cockpit.Log, err error
 	return _c
 }
 
-func (_c *MockClient_ListFunctionLogs_Call) RunAndReturn(run func(ctx context.Context, resourceName string, start time.Time, end time.Time) ([]cockpit.Log, error)) *MockClient_ListFunctionLogs_Call {
+func (_c *MockClient_ListFunctionLogs_Call) RunAndReturn(run func(ctx context.Context, resourceName string, query cockpit.LogQuery) ([]cockpit.Log, error)) *MockClient_ListFunctionLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFunctionMetrics provides a mock function for the type MockClient
+func (_mock *MockClient) ListFunctionMetrics(ctx context.Context, resourceName string, query cockpit.MetricQuery) ([]cockpit.MetricSeries, error) {
+	ret := _mock.Called(ctx, resourceName, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFunctionMetrics")
+	}
+
+	var r0 []cockpit.MetricSeries
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, cockpit.MetricQuery) ([]cockpit.MetricSeries, error)); ok {
+		return returnFunc(ctx, resourceName, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, cockpit.MetricQuery) []cockpit.MetricSeries); ok {
+		r0 = returnFunc(ctx, resourceName, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cockpit.MetricSeries)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, cockpit.MetricQuery) error); ok {
+		r1 = returnFunc(ctx, resourceName, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ListFunctionMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFunctionMetrics'
+type MockClient_ListFunctionMetrics_Call struct {
+	*mock.Call
+}
+
+// ListFunctionMetrics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resourceName string
+//   - query cockpit.MetricQuery
+func (_e *MockClient_Expecter) ListFunctionMetrics(ctx interface{}, resourceName interface{}, query interface{}) *MockClient_ListFunctionMetrics_Call {
+	return &MockClient_ListFunctionMetrics_Call{Call: _e.mock.On("ListFunctionMetrics", ctx, resourceName, query)}
+}
+
+func (_c *MockClient_ListFunctionMetrics_Call) Run(run func(ctx context.Context, resourceName string, query cockpit.MetricQuery)) *MockClient_ListFunctionMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 cockpit.MetricQuery
+		if args[2] != nil {
+			arg2 = args[2].(cockpit.MetricQuery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ListFunctionMetrics_Call) Return(metricSeriess []cockpit.MetricSeries, err error) *MockClient_ListFunctionMetrics_Call {
+	_c.Call.Return(metricSeriess, err)
+	return _c
+}
+
+func (_c *MockClient_ListFunctionMetrics_Call) RunAndReturn(run func(ctx context.Context, resourceName string, query cockpit.MetricQuery) ([]cockpit.MetricSeries, error)) *MockClient_ListFunctionMetrics_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -227,8 +295,8 @@ func (_m *MockLokiClient) EXPECT() *MockLokiClient_Expecter {
 }
 
 // Query provides a mock function for the type MockLokiClient
-func (_mock *MockLokiClient) Query(ctx context.Context, query string, start time.Time, end time.Time) ([]cockpit.Log, error) {
-	ret := _mock.Called(ctx, query, start, end)
+func (_mock *MockLokiClient) Query(ctx context.Context, req cockpit.QueryRangeRequest) ([]cockpit.Log, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Query")
@@ -236,18 +304,18 @@ func (_mock *MockLokiClient) Query(ctx context.Context, query string, start time
 
 	var r0 []cockpit.Log
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]cockpit.Log, error)); ok {
-		return returnFunc(ctx, query, start, end)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, cockpit.QueryRangeRequest) ([]cockpit.Log, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []cockpit.Log); ok {
-		r0 = returnFunc(ctx, query, start, end)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, cockpit.QueryRangeRequest) []cockpit.Log); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]cockpit.Log)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
-		r1 = returnFunc(ctx, query, start, end)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, cockpit.QueryRangeRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -261,36 +329,24 @@ type MockLokiClient_Query_Call struct {
 
 // Query is a helper method to define mock.On call
 //   - ctx context.Context
-//   - query string
-//   - start time.Time
-//   - end time.Time
-func (_e *MockLokiClient_Expecter) Query(ctx interface{}, query interface{}, start interface{}, end interface{}) *MockLokiClient_Query_Call {
-	return &MockLokiClient_Query_Call{Call: _e.mock.On("Query", ctx, query, start, end)}
+//   - req cockpit.QueryRangeRequest
+func (_e *MockLokiClient_Expecter) Query(ctx interface{}, req interface{}) *MockLokiClient_Query_Call {
+	return &MockLokiClient_Query_Call{Call: _e.mock.On("Query", ctx, req)}
 }
 
-func (_c *MockLokiClient_Query_Call) Run(run func(ctx context.Context, query string, start time.Time, end time.Time)) *MockLokiClient_Query_Call {
+func (_c *MockLokiClient_Query_Call) Run(run func(ctx context.Context, req cockpit.QueryRangeRequest)) *MockLokiClient_Query_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 cockpit.QueryRangeRequest
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 time.Time
-		if args[2] != nil {
-			arg2 = args[2].(time.Time)
-		}
-		var arg3 time.Time
-		if args[3] != nil {
-			arg3 = args[3].(time.Time)
+			arg1 = args[1].(cockpit.QueryRangeRequest)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
@@ -301,7 +357,248 @@ func (_c *MockLokiClient_Query_Call) Return(logs []cockpit.Log, err error) *Mock
 	return _c
 }
 
-func (_c *MockLokiClient_Query_Call) RunAndReturn(run func(ctx context.Context, query string, start time.Time, end time.Time) ([]cockpit.Log, error)) *MockLokiClient_Query_Call {
+func (_c *MockLokiClient_Query_Call) RunAndReturn(run func(ctx context.Context, req cockpit.QueryRangeRequest) ([]cockpit.Log, error)) *MockLokiClient_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMetricsClient creates a new instance of MockMetricsClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMetricsClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMetricsClient {
+	mock := &MockMetricsClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMetricsClient is an autogenerated mock type for the MetricsClient type
+type MockMetricsClient struct {
+	mock.Mock
+}
+
+type MockMetricsClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMetricsClient) EXPECT() *MockMetricsClient_Expecter {
+	return &MockMetricsClient_Expecter{mock: &_m.Mock}
+}
+
+// QueryRange provides a mock function for the type MockMetricsClient
+func (_mock *MockMetricsClient) QueryRange(ctx context.Context, req cockpit.MetricsQueryRangeRequest) ([]cockpit.Series, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryRange")
+	}
+
+	var r0 []cockpit.Series
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, cockpit.MetricsQueryRangeRequest) ([]cockpit.Series, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, cockpit.MetricsQueryRangeRequest) []cockpit.Series); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cockpit.Series)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, cockpit.MetricsQueryRangeRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMetricsClient_QueryRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRange'
+type MockMetricsClient_QueryRange_Call struct {
+	*mock.Call
+}
+
+// QueryRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req cockpit.MetricsQueryRangeRequest
+func (_e *MockMetricsClient_Expecter) QueryRange(ctx interface{}, req interface{}) *MockMetricsClient_QueryRange_Call {
+	return &MockMetricsClient_QueryRange_Call{Call: _e.mock.On("QueryRange", ctx, req)}
+}
+
+func (_c *MockMetricsClient_QueryRange_Call) Run(run func(ctx context.Context, req cockpit.MetricsQueryRangeRequest)) *MockMetricsClient_QueryRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 cockpit.MetricsQueryRangeRequest
+		if args[1] != nil {
+			arg1 = args[1].(cockpit.MetricsQueryRangeRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsClient_QueryRange_Call) Return(seriess []cockpit.Series, err error) *MockMetricsClient_QueryRange_Call {
+	_c.Call.Return(seriess, err)
+	return _c
+}
+
+func (_c *MockMetricsClient_QueryRange_Call) RunAndReturn(run func(ctx context.Context, req cockpit.MetricsQueryRangeRequest) ([]cockpit.Series, error)) *MockMetricsClient_QueryRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTokenStore creates a new instance of MockTokenStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTokenStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTokenStore {
+	mock := &MockTokenStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTokenStore is an autogenerated mock type for the TokenStore type
+type MockTokenStore struct {
+	mock.Mock
+}
+
+type MockTokenStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTokenStore) EXPECT() *MockTokenStore_Expecter {
+	return &MockTokenStore_Expecter{mock: &_m.Mock}
+}
+
+// Load provides a mock function for the type MockTokenStore
+func (_mock *MockTokenStore) Load(key cockpit.TokenKey) (*cockpit.Token, error) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 *cockpit.Token
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(cockpit.TokenKey) (*cockpit.Token, error)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(cockpit.TokenKey) *cockpit.Token); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cockpit.Token)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(cockpit.TokenKey) error); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTokenStore_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type MockTokenStore_Load_Call struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - key cockpit.TokenKey
+func (_e *MockTokenStore_Expecter) Load(key interface{}) *MockTokenStore_Load_Call {
+	return &MockTokenStore_Load_Call{Call: _e.mock.On("Load", key)}
+}
+
+func (_c *MockTokenStore_Load_Call) Run(run func(key cockpit.TokenKey)) *MockTokenStore_Load_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 cockpit.TokenKey
+		if args[0] != nil {
+			arg0 = args[0].(cockpit.TokenKey)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenStore_Load_Call) Return(token *cockpit.Token, err error) *MockTokenStore_Load_Call {
+	_c.Call.Return(token, err)
+	return _c
+}
+
+func (_c *MockTokenStore_Load_Call) RunAndReturn(run func(key cockpit.TokenKey) (*cockpit.Token, error)) *MockTokenStore_Load_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockTokenStore
+func (_mock *MockTokenStore) Save(key cockpit.TokenKey, token *cockpit.Token) error {
+	ret := _mock.Called(key, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(cockpit.TokenKey, *cockpit.Token) error); ok {
+		r0 = returnFunc(key, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTokenStore_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockTokenStore_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - key cockpit.TokenKey
+//   - token *cockpit.Token
+func (_e *MockTokenStore_Expecter) Save(key interface{}, token interface{}) *MockTokenStore_Save_Call {
+	return &MockTokenStore_Save_Call{Call: _e.mock.On("Save", key, token)}
+}
+
+func (_c *MockTokenStore_Save_Call) Run(run func(key cockpit.TokenKey, token *cockpit.Token)) *MockTokenStore_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 cockpit.TokenKey
+		if args[0] != nil {
+			arg0 = args[0].(cockpit.TokenKey)
+		}
+		var arg1 *cockpit.Token
+		if args[1] != nil {
+			arg1 = args[1].(*cockpit.Token)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenStore_Save_Call) Return(err error) *MockTokenStore_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTokenStore_Save_Call) RunAndReturn(run func(key cockpit.TokenKey, token *cockpit.Token) error) *MockTokenStore_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }
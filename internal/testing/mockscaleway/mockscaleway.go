@@ -10,6 +10,77 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
+// newMockscopedRequest creates a new instance of mockscopedRequest. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockscopedRequest(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockscopedRequest {
+	mock := &mockscopedRequest{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockscopedRequest is an autogenerated mock type for the scopedRequest type
+type mockscopedRequest struct {
+	mock.Mock
+}
+
+type mockscopedRequest_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockscopedRequest) EXPECT() *mockscopedRequest_Expecter {
+	return &mockscopedRequest_Expecter{mock: &_m.Mock}
+}
+
+// validateScope provides a mock function for the type mockscopedRequest
+func (_mock *mockscopedRequest) validateScope() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for validateScope")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockscopedRequest_validateScope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'validateScope'
+type mockscopedRequest_validateScope_Call struct {
+	*mock.Call
+}
+
+// validateScope is a helper method to define mock.On call
+func (_e *mockscopedRequest_Expecter) validateScope() *mockscopedRequest_validateScope_Call {
+	return &mockscopedRequest_validateScope_Call{Call: _e.mock.On("validateScope")}
+}
+
+func (_c *mockscopedRequest_validateScope_Call) Run(run func()) *mockscopedRequest_validateScope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockscopedRequest_validateScope_Call) Return(err error) *mockscopedRequest_validateScope_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockscopedRequest_validateScope_Call) RunAndReturn(run func() error) *mockscopedRequest_validateScope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockFunctionAPI creates a new instance of MockFunctionAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockFunctionAPI(t interface {
@@ -37,6 +108,160 @@ func (_m *MockFunctionAPI) EXPECT() *MockFunctionAPI_Expecter {
 	return &MockFunctionAPI_Expecter{mock: &_m.Mock}
 }
 
+// CreateCron provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) CreateCron(createCronRequest *function.CreateCronRequest, requestOptions ...scw.RequestOption) (*function.Cron, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(createCronRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(createCronRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCron")
+	}
+
+	var r0 *function.Cron
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.CreateCronRequest, ...scw.RequestOption) (*function.Cron, error)); ok {
+		return returnFunc(createCronRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.CreateCronRequest, ...scw.RequestOption) *function.Cron); ok {
+		r0 = returnFunc(createCronRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Cron)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.CreateCronRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(createCronRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_CreateCron_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCron'
+type MockFunctionAPI_CreateCron_Call struct {
+	*mock.Call
+}
+
+// CreateCron is a helper method to define mock.On call
+//   - createCronRequest *function.CreateCronRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) CreateCron(createCronRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_CreateCron_Call {
+	return &MockFunctionAPI_CreateCron_Call{Call: _e.mock.On("CreateCron",
+		append([]interface{}{createCronRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_CreateCron_Call) Run(run func(createCronRequest *function.CreateCronRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_CreateCron_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.CreateCronRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.CreateCronRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_CreateCron_Call) Return(cron *function.Cron, err error) *MockFunctionAPI_CreateCron_Call {
+	_c.Call.Return(cron, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_CreateCron_Call) RunAndReturn(run func(createCronRequest *function.CreateCronRequest, requestOptions ...scw.RequestOption) (*function.Cron, error)) *MockFunctionAPI_CreateCron_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDomain provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) CreateDomain(createDomainRequest *function.CreateDomainRequest, requestOptions ...scw.RequestOption) (*function.Domain, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(createDomainRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(createDomainRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDomain")
+	}
+
+	var r0 *function.Domain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.CreateDomainRequest, ...scw.RequestOption) (*function.Domain, error)); ok {
+		return returnFunc(createDomainRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.CreateDomainRequest, ...scw.RequestOption) *function.Domain); ok {
+		r0 = returnFunc(createDomainRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Domain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.CreateDomainRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(createDomainRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_CreateDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDomain'
+type MockFunctionAPI_CreateDomain_Call struct {
+	*mock.Call
+}
+
+// CreateDomain is a helper method to define mock.On call
+//   - createDomainRequest *function.CreateDomainRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) CreateDomain(createDomainRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_CreateDomain_Call {
+	return &MockFunctionAPI_CreateDomain_Call{Call: _e.mock.On("CreateDomain",
+		append([]interface{}{createDomainRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_CreateDomain_Call) Run(run func(createDomainRequest *function.CreateDomainRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_CreateDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.CreateDomainRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.CreateDomainRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_CreateDomain_Call) Return(domain *function.Domain, err error) *MockFunctionAPI_CreateDomain_Call {
+	_c.Call.Return(domain, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_CreateDomain_Call) RunAndReturn(run func(createDomainRequest *function.CreateDomainRequest, requestOptions ...scw.RequestOption) (*function.Domain, error)) *MockFunctionAPI_CreateDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateFunction provides a mock function for the type MockFunctionAPI
 func (_mock *MockFunctionAPI) CreateFunction(createFunctionRequest *function.CreateFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
 	var tmpRet mock.Arguments
@@ -191,58 +416,58 @@ func (_c *MockFunctionAPI_CreateNamespace_Call) RunAndReturn(run func(createName
 	return _c
 }
 
-// DeleteFunction provides a mock function for the type MockFunctionAPI
-func (_mock *MockFunctionAPI) DeleteFunction(deleteFunctionRequest *function.DeleteFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
+// CreateToken provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) CreateToken(createTokenRequest *function.CreateTokenRequest, requestOptions ...scw.RequestOption) (*function.Token, error) {
 	var tmpRet mock.Arguments
 	if len(requestOptions) > 0 {
-		tmpRet = _mock.Called(deleteFunctionRequest, requestOptions)
+		tmpRet = _mock.Called(createTokenRequest, requestOptions)
 	} else {
-		tmpRet = _mock.Called(deleteFunctionRequest)
+		tmpRet = _mock.Called(createTokenRequest)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFunction")
+		panic("no return value specified for CreateToken")
 	}
 
-	var r0 *function.Function
+	var r0 *function.Token
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*function.DeleteFunctionRequest, ...scw.RequestOption) (*function.Function, error)); ok {
-		return returnFunc(deleteFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.CreateTokenRequest, ...scw.RequestOption) (*function.Token, error)); ok {
+		return returnFunc(createTokenRequest, requestOptions...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*function.DeleteFunctionRequest, ...scw.RequestOption) *function.Function); ok {
-		r0 = returnFunc(deleteFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.CreateTokenRequest, ...scw.RequestOption) *function.Token); ok {
+		r0 = returnFunc(createTokenRequest, requestOptions...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*function.Function)
+			r0 = ret.Get(0).(*function.Token)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*function.DeleteFunctionRequest, ...scw.RequestOption) error); ok {
-		r1 = returnFunc(deleteFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(1).(func(*function.CreateTokenRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(createTokenRequest, requestOptions...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFunctionAPI_DeleteFunction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFunction'
-type MockFunctionAPI_DeleteFunction_Call struct {
+// MockFunctionAPI_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type MockFunctionAPI_CreateToken_Call struct {
 	*mock.Call
 }
 
-// DeleteFunction is a helper method to define mock.On call
-//   - deleteFunctionRequest *function.DeleteFunctionRequest
+// CreateToken is a helper method to define mock.On call
+//   - createTokenRequest *function.CreateTokenRequest
 //   - requestOptions ...scw.RequestOption
-func (_e *MockFunctionAPI_Expecter) DeleteFunction(deleteFunctionRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeleteFunction_Call {
-	return &MockFunctionAPI_DeleteFunction_Call{Call: _e.mock.On("DeleteFunction",
-		append([]interface{}{deleteFunctionRequest}, requestOptions...)...)}
+func (_e *MockFunctionAPI_Expecter) CreateToken(createTokenRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_CreateToken_Call {
+	return &MockFunctionAPI_CreateToken_Call{Call: _e.mock.On("CreateToken",
+		append([]interface{}{createTokenRequest}, requestOptions...)...)}
 }
 
-func (_c *MockFunctionAPI_DeleteFunction_Call) Run(run func(deleteFunctionRequest *function.DeleteFunctionRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeleteFunction_Call {
+func (_c *MockFunctionAPI_CreateToken_Call) Run(run func(createTokenRequest *function.CreateTokenRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_CreateToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *function.DeleteFunctionRequest
+		var arg0 *function.CreateTokenRequest
 		if args[0] != nil {
-			arg0 = args[0].(*function.DeleteFunctionRequest)
+			arg0 = args[0].(*function.CreateTokenRequest)
 		}
 		var arg1 []scw.RequestOption
 		var variadicArgs []scw.RequestOption
@@ -258,68 +483,68 @@ func (_c *MockFunctionAPI_DeleteFunction_Call) Run(run func(deleteFunctionReques
 	return _c
 }
 
-func (_c *MockFunctionAPI_DeleteFunction_Call) Return(function1 *function.Function, err error) *MockFunctionAPI_DeleteFunction_Call {
-	_c.Call.Return(function1, err)
+func (_c *MockFunctionAPI_CreateToken_Call) Return(token *function.Token, err error) *MockFunctionAPI_CreateToken_Call {
+	_c.Call.Return(token, err)
 	return _c
 }
 
-func (_c *MockFunctionAPI_DeleteFunction_Call) RunAndReturn(run func(deleteFunctionRequest *function.DeleteFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error)) *MockFunctionAPI_DeleteFunction_Call {
+func (_c *MockFunctionAPI_CreateToken_Call) RunAndReturn(run func(createTokenRequest *function.CreateTokenRequest, requestOptions ...scw.RequestOption) (*function.Token, error)) *MockFunctionAPI_CreateToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteNamespace provides a mock function for the type MockFunctionAPI
-func (_mock *MockFunctionAPI) DeleteNamespace(deleteNamespaceRequest *function.DeleteNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error) {
+// DeleteCron provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) DeleteCron(deleteCronRequest *function.DeleteCronRequest, requestOptions ...scw.RequestOption) (*function.Cron, error) {
 	var tmpRet mock.Arguments
 	if len(requestOptions) > 0 {
-		tmpRet = _mock.Called(deleteNamespaceRequest, requestOptions)
+		tmpRet = _mock.Called(deleteCronRequest, requestOptions)
 	} else {
-		tmpRet = _mock.Called(deleteNamespaceRequest)
+		tmpRet = _mock.Called(deleteCronRequest)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteNamespace")
+		panic("no return value specified for DeleteCron")
 	}
 
-	var r0 *function.Namespace
+	var r0 *function.Cron
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*function.DeleteNamespaceRequest, ...scw.RequestOption) (*function.Namespace, error)); ok {
-		return returnFunc(deleteNamespaceRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteCronRequest, ...scw.RequestOption) (*function.Cron, error)); ok {
+		return returnFunc(deleteCronRequest, requestOptions...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*function.DeleteNamespaceRequest, ...scw.RequestOption) *function.Namespace); ok {
-		r0 = returnFunc(deleteNamespaceRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteCronRequest, ...scw.RequestOption) *function.Cron); ok {
+		r0 = returnFunc(deleteCronRequest, requestOptions...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*function.Namespace)
+			r0 = ret.Get(0).(*function.Cron)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*function.DeleteNamespaceRequest, ...scw.RequestOption) error); ok {
-		r1 = returnFunc(deleteNamespaceRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(1).(func(*function.DeleteCronRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(deleteCronRequest, requestOptions...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFunctionAPI_DeleteNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNamespace'
-type MockFunctionAPI_DeleteNamespace_Call struct {
+// MockFunctionAPI_DeleteCron_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCron'
+type MockFunctionAPI_DeleteCron_Call struct {
 	*mock.Call
 }
 
-// DeleteNamespace is a helper method to define mock.On call
-//   - deleteNamespaceRequest *function.DeleteNamespaceRequest
+// DeleteCron is a helper method to define mock.On call
+//   - deleteCronRequest *function.DeleteCronRequest
 //   - requestOptions ...scw.RequestOption
-func (_e *MockFunctionAPI_Expecter) DeleteNamespace(deleteNamespaceRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeleteNamespace_Call {
-	return &MockFunctionAPI_DeleteNamespace_Call{Call: _e.mock.On("DeleteNamespace",
-		append([]interface{}{deleteNamespaceRequest}, requestOptions...)...)}
+func (_e *MockFunctionAPI_Expecter) DeleteCron(deleteCronRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeleteCron_Call {
+	return &MockFunctionAPI_DeleteCron_Call{Call: _e.mock.On("DeleteCron",
+		append([]interface{}{deleteCronRequest}, requestOptions...)...)}
 }
 
-func (_c *MockFunctionAPI_DeleteNamespace_Call) Run(run func(deleteNamespaceRequest *function.DeleteNamespaceRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeleteNamespace_Call {
+func (_c *MockFunctionAPI_DeleteCron_Call) Run(run func(deleteCronRequest *function.DeleteCronRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeleteCron_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *function.DeleteNamespaceRequest
+		var arg0 *function.DeleteCronRequest
 		if args[0] != nil {
-			arg0 = args[0].(*function.DeleteNamespaceRequest)
+			arg0 = args[0].(*function.DeleteCronRequest)
 		}
 		var arg1 []scw.RequestOption
 		var variadicArgs []scw.RequestOption
@@ -335,68 +560,68 @@ func (_c *MockFunctionAPI_DeleteNamespace_Call) Run(run func(deleteNamespaceRequ
 	return _c
 }
 
-func (_c *MockFunctionAPI_DeleteNamespace_Call) Return(namespace *function.Namespace, err error) *MockFunctionAPI_DeleteNamespace_Call {
-	_c.Call.Return(namespace, err)
+func (_c *MockFunctionAPI_DeleteCron_Call) Return(cron *function.Cron, err error) *MockFunctionAPI_DeleteCron_Call {
+	_c.Call.Return(cron, err)
 	return _c
 }
 
-func (_c *MockFunctionAPI_DeleteNamespace_Call) RunAndReturn(run func(deleteNamespaceRequest *function.DeleteNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error)) *MockFunctionAPI_DeleteNamespace_Call {
+func (_c *MockFunctionAPI_DeleteCron_Call) RunAndReturn(run func(deleteCronRequest *function.DeleteCronRequest, requestOptions ...scw.RequestOption) (*function.Cron, error)) *MockFunctionAPI_DeleteCron_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeployFunction provides a mock function for the type MockFunctionAPI
-func (_mock *MockFunctionAPI) DeployFunction(deployFunctionRequest *function.DeployFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
+// DeleteDomain provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) DeleteDomain(deleteDomainRequest *function.DeleteDomainRequest, requestOptions ...scw.RequestOption) (*function.Domain, error) {
 	var tmpRet mock.Arguments
 	if len(requestOptions) > 0 {
-		tmpRet = _mock.Called(deployFunctionRequest, requestOptions)
+		tmpRet = _mock.Called(deleteDomainRequest, requestOptions)
 	} else {
-		tmpRet = _mock.Called(deployFunctionRequest)
+		tmpRet = _mock.Called(deleteDomainRequest)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeployFunction")
+		panic("no return value specified for DeleteDomain")
 	}
 
-	var r0 *function.Function
+	var r0 *function.Domain
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*function.DeployFunctionRequest, ...scw.RequestOption) (*function.Function, error)); ok {
-		return returnFunc(deployFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteDomainRequest, ...scw.RequestOption) (*function.Domain, error)); ok {
+		return returnFunc(deleteDomainRequest, requestOptions...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*function.DeployFunctionRequest, ...scw.RequestOption) *function.Function); ok {
-		r0 = returnFunc(deployFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteDomainRequest, ...scw.RequestOption) *function.Domain); ok {
+		r0 = returnFunc(deleteDomainRequest, requestOptions...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*function.Function)
+			r0 = ret.Get(0).(*function.Domain)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*function.DeployFunctionRequest, ...scw.RequestOption) error); ok {
-		r1 = returnFunc(deployFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(1).(func(*function.DeleteDomainRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(deleteDomainRequest, requestOptions...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFunctionAPI_DeployFunction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeployFunction'
-type MockFunctionAPI_DeployFunction_Call struct {
+// MockFunctionAPI_DeleteDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDomain'
+type MockFunctionAPI_DeleteDomain_Call struct {
 	*mock.Call
 }
 
-// DeployFunction is a helper method to define mock.On call
-//   - deployFunctionRequest *function.DeployFunctionRequest
+// DeleteDomain is a helper method to define mock.On call
+//   - deleteDomainRequest *function.DeleteDomainRequest
 //   - requestOptions ...scw.RequestOption
-func (_e *MockFunctionAPI_Expecter) DeployFunction(deployFunctionRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeployFunction_Call {
-	return &MockFunctionAPI_DeployFunction_Call{Call: _e.mock.On("DeployFunction",
-		append([]interface{}{deployFunctionRequest}, requestOptions...)...)}
+func (_e *MockFunctionAPI_Expecter) DeleteDomain(deleteDomainRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeleteDomain_Call {
+	return &MockFunctionAPI_DeleteDomain_Call{Call: _e.mock.On("DeleteDomain",
+		append([]interface{}{deleteDomainRequest}, requestOptions...)...)}
 }
 
-func (_c *MockFunctionAPI_DeployFunction_Call) Run(run func(deployFunctionRequest *function.DeployFunctionRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeployFunction_Call {
+func (_c *MockFunctionAPI_DeleteDomain_Call) Run(run func(deleteDomainRequest *function.DeleteDomainRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeleteDomain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *function.DeployFunctionRequest
+		var arg0 *function.DeleteDomainRequest
 		if args[0] != nil {
-			arg0 = args[0].(*function.DeployFunctionRequest)
+			arg0 = args[0].(*function.DeleteDomainRequest)
 		}
 		var arg1 []scw.RequestOption
 		var variadicArgs []scw.RequestOption
@@ -412,61 +637,369 @@ func (_c *MockFunctionAPI_DeployFunction_Call) Run(run func(deployFunctionReques
 	return _c
 }
 
-func (_c *MockFunctionAPI_DeployFunction_Call) Return(function1 *function.Function, err error) *MockFunctionAPI_DeployFunction_Call {
-	_c.Call.Return(function1, err)
+func (_c *MockFunctionAPI_DeleteDomain_Call) Return(domain *function.Domain, err error) *MockFunctionAPI_DeleteDomain_Call {
+	_c.Call.Return(domain, err)
 	return _c
 }
 
-func (_c *MockFunctionAPI_DeployFunction_Call) RunAndReturn(run func(deployFunctionRequest *function.DeployFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error)) *MockFunctionAPI_DeployFunction_Call {
+func (_c *MockFunctionAPI_DeleteDomain_Call) RunAndReturn(run func(deleteDomainRequest *function.DeleteDomainRequest, requestOptions ...scw.RequestOption) (*function.Domain, error)) *MockFunctionAPI_DeleteDomain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFunction provides a mock function for the type MockFunctionAPI
-func (_mock *MockFunctionAPI) GetFunction(getFunctionRequest *function.GetFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
+// DeleteFunction provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) DeleteFunction(deleteFunctionRequest *function.DeleteFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
 	var tmpRet mock.Arguments
 	if len(requestOptions) > 0 {
-		tmpRet = _mock.Called(getFunctionRequest, requestOptions)
+		tmpRet = _mock.Called(deleteFunctionRequest, requestOptions)
 	} else {
-		tmpRet = _mock.Called(getFunctionRequest)
+		tmpRet = _mock.Called(deleteFunctionRequest)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFunction")
+		panic("no return value specified for DeleteFunction")
 	}
 
 	var r0 *function.Function
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionRequest, ...scw.RequestOption) (*function.Function, error)); ok {
-		return returnFunc(getFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteFunctionRequest, ...scw.RequestOption) (*function.Function, error)); ok {
+		return returnFunc(deleteFunctionRequest, requestOptions...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionRequest, ...scw.RequestOption) *function.Function); ok {
-		r0 = returnFunc(getFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteFunctionRequest, ...scw.RequestOption) *function.Function); ok {
+		r0 = returnFunc(deleteFunctionRequest, requestOptions...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*function.Function)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*function.GetFunctionRequest, ...scw.RequestOption) error); ok {
-		r1 = returnFunc(getFunctionRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(1).(func(*function.DeleteFunctionRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(deleteFunctionRequest, requestOptions...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFunctionAPI_GetFunction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunction'
-type MockFunctionAPI_GetFunction_Call struct {
+// MockFunctionAPI_DeleteFunction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFunction'
+type MockFunctionAPI_DeleteFunction_Call struct {
 	*mock.Call
 }
 
-// GetFunction is a helper method to define mock.On call
-//   - getFunctionRequest *function.GetFunctionRequest
+// DeleteFunction is a helper method to define mock.On call
+//   - deleteFunctionRequest *function.DeleteFunctionRequest
 //   - requestOptions ...scw.RequestOption
-func (_e *MockFunctionAPI_Expecter) GetFunction(getFunctionRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetFunction_Call {
-	return &MockFunctionAPI_GetFunction_Call{Call: _e.mock.On("GetFunction",
-		append([]interface{}{getFunctionRequest}, requestOptions...)...)}
+func (_e *MockFunctionAPI_Expecter) DeleteFunction(deleteFunctionRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeleteFunction_Call {
+	return &MockFunctionAPI_DeleteFunction_Call{Call: _e.mock.On("DeleteFunction",
+		append([]interface{}{deleteFunctionRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_DeleteFunction_Call) Run(run func(deleteFunctionRequest *function.DeleteFunctionRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeleteFunction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.DeleteFunctionRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.DeleteFunctionRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeleteFunction_Call) Return(function1 *function.Function, err error) *MockFunctionAPI_DeleteFunction_Call {
+	_c.Call.Return(function1, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeleteFunction_Call) RunAndReturn(run func(deleteFunctionRequest *function.DeleteFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error)) *MockFunctionAPI_DeleteFunction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNamespace provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) DeleteNamespace(deleteNamespaceRequest *function.DeleteNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(deleteNamespaceRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(deleteNamespaceRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNamespace")
+	}
+
+	var r0 *function.Namespace
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteNamespaceRequest, ...scw.RequestOption) (*function.Namespace, error)); ok {
+		return returnFunc(deleteNamespaceRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteNamespaceRequest, ...scw.RequestOption) *function.Namespace); ok {
+		r0 = returnFunc(deleteNamespaceRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Namespace)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.DeleteNamespaceRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(deleteNamespaceRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_DeleteNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNamespace'
+type MockFunctionAPI_DeleteNamespace_Call struct {
+	*mock.Call
+}
+
+// DeleteNamespace is a helper method to define mock.On call
+//   - deleteNamespaceRequest *function.DeleteNamespaceRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) DeleteNamespace(deleteNamespaceRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeleteNamespace_Call {
+	return &MockFunctionAPI_DeleteNamespace_Call{Call: _e.mock.On("DeleteNamespace",
+		append([]interface{}{deleteNamespaceRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_DeleteNamespace_Call) Run(run func(deleteNamespaceRequest *function.DeleteNamespaceRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeleteNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.DeleteNamespaceRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.DeleteNamespaceRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeleteNamespace_Call) Return(namespace *function.Namespace, err error) *MockFunctionAPI_DeleteNamespace_Call {
+	_c.Call.Return(namespace, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeleteNamespace_Call) RunAndReturn(run func(deleteNamespaceRequest *function.DeleteNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error)) *MockFunctionAPI_DeleteNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteToken provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) DeleteToken(deleteTokenRequest *function.DeleteTokenRequest, requestOptions ...scw.RequestOption) (*function.Token, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(deleteTokenRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(deleteTokenRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteToken")
+	}
+
+	var r0 *function.Token
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteTokenRequest, ...scw.RequestOption) (*function.Token, error)); ok {
+		return returnFunc(deleteTokenRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.DeleteTokenRequest, ...scw.RequestOption) *function.Token); ok {
+		r0 = returnFunc(deleteTokenRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Token)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.DeleteTokenRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(deleteTokenRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_DeleteToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteToken'
+type MockFunctionAPI_DeleteToken_Call struct {
+	*mock.Call
+}
+
+// DeleteToken is a helper method to define mock.On call
+//   - deleteTokenRequest *function.DeleteTokenRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) DeleteToken(deleteTokenRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeleteToken_Call {
+	return &MockFunctionAPI_DeleteToken_Call{Call: _e.mock.On("DeleteToken",
+		append([]interface{}{deleteTokenRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_DeleteToken_Call) Run(run func(deleteTokenRequest *function.DeleteTokenRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeleteToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.DeleteTokenRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.DeleteTokenRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeleteToken_Call) Return(token *function.Token, err error) *MockFunctionAPI_DeleteToken_Call {
+	_c.Call.Return(token, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeleteToken_Call) RunAndReturn(run func(deleteTokenRequest *function.DeleteTokenRequest, requestOptions ...scw.RequestOption) (*function.Token, error)) *MockFunctionAPI_DeleteToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeployFunction provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) DeployFunction(deployFunctionRequest *function.DeployFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(deployFunctionRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(deployFunctionRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeployFunction")
+	}
+
+	var r0 *function.Function
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.DeployFunctionRequest, ...scw.RequestOption) (*function.Function, error)); ok {
+		return returnFunc(deployFunctionRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.DeployFunctionRequest, ...scw.RequestOption) *function.Function); ok {
+		r0 = returnFunc(deployFunctionRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Function)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.DeployFunctionRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(deployFunctionRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_DeployFunction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeployFunction'
+type MockFunctionAPI_DeployFunction_Call struct {
+	*mock.Call
+}
+
+// DeployFunction is a helper method to define mock.On call
+//   - deployFunctionRequest *function.DeployFunctionRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) DeployFunction(deployFunctionRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_DeployFunction_Call {
+	return &MockFunctionAPI_DeployFunction_Call{Call: _e.mock.On("DeployFunction",
+		append([]interface{}{deployFunctionRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_DeployFunction_Call) Run(run func(deployFunctionRequest *function.DeployFunctionRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_DeployFunction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.DeployFunctionRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.DeployFunctionRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeployFunction_Call) Return(function1 *function.Function, err error) *MockFunctionAPI_DeployFunction_Call {
+	_c.Call.Return(function1, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_DeployFunction_Call) RunAndReturn(run func(deployFunctionRequest *function.DeployFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error)) *MockFunctionAPI_DeployFunction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFunction provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) GetFunction(getFunctionRequest *function.GetFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(getFunctionRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(getFunctionRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunction")
+	}
+
+	var r0 *function.Function
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionRequest, ...scw.RequestOption) (*function.Function, error)); ok {
+		return returnFunc(getFunctionRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionRequest, ...scw.RequestOption) *function.Function); ok {
+		r0 = returnFunc(getFunctionRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Function)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.GetFunctionRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(getFunctionRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_GetFunction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunction'
+type MockFunctionAPI_GetFunction_Call struct {
+	*mock.Call
+}
+
+// GetFunction is a helper method to define mock.On call
+//   - getFunctionRequest *function.GetFunctionRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) GetFunction(getFunctionRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetFunction_Call {
+	return &MockFunctionAPI_GetFunction_Call{Call: _e.mock.On("GetFunction",
+		append([]interface{}{getFunctionRequest}, requestOptions...)...)}
 }
 
 func (_c *MockFunctionAPI_GetFunction_Call) Run(run func(getFunctionRequest *function.GetFunctionRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetFunction_Call {
@@ -503,54 +1036,285 @@ func (_c *MockFunctionAPI_GetFunction_Call) RunAndReturn(run func(getFunctionReq
 func (_mock *MockFunctionAPI) GetFunctionDownloadURL(getFunctionDownloadURLRequest *function.GetFunctionDownloadURLRequest, requestOptions ...scw.RequestOption) (*function.DownloadURL, error) {
 	var tmpRet mock.Arguments
 	if len(requestOptions) > 0 {
-		tmpRet = _mock.Called(getFunctionDownloadURLRequest, requestOptions)
+		tmpRet = _mock.Called(getFunctionDownloadURLRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(getFunctionDownloadURLRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunctionDownloadURL")
+	}
+
+	var r0 *function.DownloadURL
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionDownloadURLRequest, ...scw.RequestOption) (*function.DownloadURL, error)); ok {
+		return returnFunc(getFunctionDownloadURLRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionDownloadURLRequest, ...scw.RequestOption) *function.DownloadURL); ok {
+		r0 = returnFunc(getFunctionDownloadURLRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.DownloadURL)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.GetFunctionDownloadURLRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(getFunctionDownloadURLRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_GetFunctionDownloadURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunctionDownloadURL'
+type MockFunctionAPI_GetFunctionDownloadURL_Call struct {
+	*mock.Call
+}
+
+// GetFunctionDownloadURL is a helper method to define mock.On call
+//   - getFunctionDownloadURLRequest *function.GetFunctionDownloadURLRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) GetFunctionDownloadURL(getFunctionDownloadURLRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetFunctionDownloadURL_Call {
+	return &MockFunctionAPI_GetFunctionDownloadURL_Call{Call: _e.mock.On("GetFunctionDownloadURL",
+		append([]interface{}{getFunctionDownloadURLRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_GetFunctionDownloadURL_Call) Run(run func(getFunctionDownloadURLRequest *function.GetFunctionDownloadURLRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetFunctionDownloadURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.GetFunctionDownloadURLRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.GetFunctionDownloadURLRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_GetFunctionDownloadURL_Call) Return(downloadURL *function.DownloadURL, err error) *MockFunctionAPI_GetFunctionDownloadURL_Call {
+	_c.Call.Return(downloadURL, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_GetFunctionDownloadURL_Call) RunAndReturn(run func(getFunctionDownloadURLRequest *function.GetFunctionDownloadURLRequest, requestOptions ...scw.RequestOption) (*function.DownloadURL, error)) *MockFunctionAPI_GetFunctionDownloadURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFunctionUploadURL provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) GetFunctionUploadURL(getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest, requestOptions ...scw.RequestOption) (*function.UploadURL, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(getFunctionUploadURLRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(getFunctionUploadURLRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunctionUploadURL")
+	}
+
+	var r0 *function.UploadURL
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionUploadURLRequest, ...scw.RequestOption) (*function.UploadURL, error)); ok {
+		return returnFunc(getFunctionUploadURLRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionUploadURLRequest, ...scw.RequestOption) *function.UploadURL); ok {
+		r0 = returnFunc(getFunctionUploadURLRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.UploadURL)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.GetFunctionUploadURLRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(getFunctionUploadURLRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_GetFunctionUploadURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunctionUploadURL'
+type MockFunctionAPI_GetFunctionUploadURL_Call struct {
+	*mock.Call
+}
+
+// GetFunctionUploadURL is a helper method to define mock.On call
+//   - getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) GetFunctionUploadURL(getFunctionUploadURLRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetFunctionUploadURL_Call {
+	return &MockFunctionAPI_GetFunctionUploadURL_Call{Call: _e.mock.On("GetFunctionUploadURL",
+		append([]interface{}{getFunctionUploadURLRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_GetFunctionUploadURL_Call) Run(run func(getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetFunctionUploadURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.GetFunctionUploadURLRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.GetFunctionUploadURLRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_GetFunctionUploadURL_Call) Return(uploadURL *function.UploadURL, err error) *MockFunctionAPI_GetFunctionUploadURL_Call {
+	_c.Call.Return(uploadURL, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_GetFunctionUploadURL_Call) RunAndReturn(run func(getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest, requestOptions ...scw.RequestOption) (*function.UploadURL, error)) *MockFunctionAPI_GetFunctionUploadURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNamespace provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) GetNamespace(getNamespaceRequest *function.GetNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(getNamespaceRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(getNamespaceRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespace")
+	}
+
+	var r0 *function.Namespace
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.GetNamespaceRequest, ...scw.RequestOption) (*function.Namespace, error)); ok {
+		return returnFunc(getNamespaceRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.GetNamespaceRequest, ...scw.RequestOption) *function.Namespace); ok {
+		r0 = returnFunc(getNamespaceRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Namespace)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.GetNamespaceRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(getNamespaceRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_GetNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNamespace'
+type MockFunctionAPI_GetNamespace_Call struct {
+	*mock.Call
+}
+
+// GetNamespace is a helper method to define mock.On call
+//   - getNamespaceRequest *function.GetNamespaceRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) GetNamespace(getNamespaceRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetNamespace_Call {
+	return &MockFunctionAPI_GetNamespace_Call{Call: _e.mock.On("GetNamespace",
+		append([]interface{}{getNamespaceRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_GetNamespace_Call) Run(run func(getNamespaceRequest *function.GetNamespaceRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.GetNamespaceRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.GetNamespaceRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_GetNamespace_Call) Return(namespace *function.Namespace, err error) *MockFunctionAPI_GetNamespace_Call {
+	_c.Call.Return(namespace, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_GetNamespace_Call) RunAndReturn(run func(getNamespaceRequest *function.GetNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error)) *MockFunctionAPI_GetNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetToken provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) GetToken(getTokenRequest *function.GetTokenRequest, requestOptions ...scw.RequestOption) (*function.Token, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(getTokenRequest, requestOptions)
 	} else {
-		tmpRet = _mock.Called(getFunctionDownloadURLRequest)
+		tmpRet = _mock.Called(getTokenRequest)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFunctionDownloadURL")
+		panic("no return value specified for GetToken")
 	}
 
-	var r0 *function.DownloadURL
+	var r0 *function.Token
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionDownloadURLRequest, ...scw.RequestOption) (*function.DownloadURL, error)); ok {
-		return returnFunc(getFunctionDownloadURLRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.GetTokenRequest, ...scw.RequestOption) (*function.Token, error)); ok {
+		return returnFunc(getTokenRequest, requestOptions...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionDownloadURLRequest, ...scw.RequestOption) *function.DownloadURL); ok {
-		r0 = returnFunc(getFunctionDownloadURLRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.GetTokenRequest, ...scw.RequestOption) *function.Token); ok {
+		r0 = returnFunc(getTokenRequest, requestOptions...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*function.DownloadURL)
+			r0 = ret.Get(0).(*function.Token)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*function.GetFunctionDownloadURLRequest, ...scw.RequestOption) error); ok {
-		r1 = returnFunc(getFunctionDownloadURLRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(1).(func(*function.GetTokenRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(getTokenRequest, requestOptions...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFunctionAPI_GetFunctionDownloadURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunctionDownloadURL'
-type MockFunctionAPI_GetFunctionDownloadURL_Call struct {
+// MockFunctionAPI_GetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetToken'
+type MockFunctionAPI_GetToken_Call struct {
 	*mock.Call
 }
 
-// GetFunctionDownloadURL is a helper method to define mock.On call
-//   - getFunctionDownloadURLRequest *function.GetFunctionDownloadURLRequest
+// GetToken is a helper method to define mock.On call
+//   - getTokenRequest *function.GetTokenRequest
 //   - requestOptions ...scw.RequestOption
-func (_e *MockFunctionAPI_Expecter) GetFunctionDownloadURL(getFunctionDownloadURLRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetFunctionDownloadURL_Call {
-	return &MockFunctionAPI_GetFunctionDownloadURL_Call{Call: _e.mock.On("GetFunctionDownloadURL",
-		append([]interface{}{getFunctionDownloadURLRequest}, requestOptions...)...)}
+func (_e *MockFunctionAPI_Expecter) GetToken(getTokenRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetToken_Call {
+	return &MockFunctionAPI_GetToken_Call{Call: _e.mock.On("GetToken",
+		append([]interface{}{getTokenRequest}, requestOptions...)...)}
 }
 
-func (_c *MockFunctionAPI_GetFunctionDownloadURL_Call) Run(run func(getFunctionDownloadURLRequest *function.GetFunctionDownloadURLRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetFunctionDownloadURL_Call {
+func (_c *MockFunctionAPI_GetToken_Call) Run(run func(getTokenRequest *function.GetTokenRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *function.GetFunctionDownloadURLRequest
+		var arg0 *function.GetTokenRequest
 		if args[0] != nil {
-			arg0 = args[0].(*function.GetFunctionDownloadURLRequest)
+			arg0 = args[0].(*function.GetTokenRequest)
 		}
 		var arg1 []scw.RequestOption
 		var variadicArgs []scw.RequestOption
@@ -566,68 +1330,68 @@ func (_c *MockFunctionAPI_GetFunctionDownloadURL_Call) Run(run func(getFunctionD
 	return _c
 }
 
-func (_c *MockFunctionAPI_GetFunctionDownloadURL_Call) Return(downloadURL *function.DownloadURL, err error) *MockFunctionAPI_GetFunctionDownloadURL_Call {
-	_c.Call.Return(downloadURL, err)
+func (_c *MockFunctionAPI_GetToken_Call) Return(token *function.Token, err error) *MockFunctionAPI_GetToken_Call {
+	_c.Call.Return(token, err)
 	return _c
 }
 
-func (_c *MockFunctionAPI_GetFunctionDownloadURL_Call) RunAndReturn(run func(getFunctionDownloadURLRequest *function.GetFunctionDownloadURLRequest, requestOptions ...scw.RequestOption) (*function.DownloadURL, error)) *MockFunctionAPI_GetFunctionDownloadURL_Call {
+func (_c *MockFunctionAPI_GetToken_Call) RunAndReturn(run func(getTokenRequest *function.GetTokenRequest, requestOptions ...scw.RequestOption) (*function.Token, error)) *MockFunctionAPI_GetToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFunctionUploadURL provides a mock function for the type MockFunctionAPI
-func (_mock *MockFunctionAPI) GetFunctionUploadURL(getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest, requestOptions ...scw.RequestOption) (*function.UploadURL, error) {
+// ListCrons provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) ListCrons(listCronsRequest *function.ListCronsRequest, requestOptions ...scw.RequestOption) (*function.ListCronsResponse, error) {
 	var tmpRet mock.Arguments
 	if len(requestOptions) > 0 {
-		tmpRet = _mock.Called(getFunctionUploadURLRequest, requestOptions)
+		tmpRet = _mock.Called(listCronsRequest, requestOptions)
 	} else {
-		tmpRet = _mock.Called(getFunctionUploadURLRequest)
+		tmpRet = _mock.Called(listCronsRequest)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFunctionUploadURL")
+		panic("no return value specified for ListCrons")
 	}
 
-	var r0 *function.UploadURL
+	var r0 *function.ListCronsResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionUploadURLRequest, ...scw.RequestOption) (*function.UploadURL, error)); ok {
-		return returnFunc(getFunctionUploadURLRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.ListCronsRequest, ...scw.RequestOption) (*function.ListCronsResponse, error)); ok {
+		return returnFunc(listCronsRequest, requestOptions...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*function.GetFunctionUploadURLRequest, ...scw.RequestOption) *function.UploadURL); ok {
-		r0 = returnFunc(getFunctionUploadURLRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.ListCronsRequest, ...scw.RequestOption) *function.ListCronsResponse); ok {
+		r0 = returnFunc(listCronsRequest, requestOptions...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*function.UploadURL)
+			r0 = ret.Get(0).(*function.ListCronsResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*function.GetFunctionUploadURLRequest, ...scw.RequestOption) error); ok {
-		r1 = returnFunc(getFunctionUploadURLRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(1).(func(*function.ListCronsRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(listCronsRequest, requestOptions...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFunctionAPI_GetFunctionUploadURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunctionUploadURL'
-type MockFunctionAPI_GetFunctionUploadURL_Call struct {
+// MockFunctionAPI_ListCrons_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCrons'
+type MockFunctionAPI_ListCrons_Call struct {
 	*mock.Call
 }
 
-// GetFunctionUploadURL is a helper method to define mock.On call
-//   - getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest
+// ListCrons is a helper method to define mock.On call
+//   - listCronsRequest *function.ListCronsRequest
 //   - requestOptions ...scw.RequestOption
-func (_e *MockFunctionAPI_Expecter) GetFunctionUploadURL(getFunctionUploadURLRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetFunctionUploadURL_Call {
-	return &MockFunctionAPI_GetFunctionUploadURL_Call{Call: _e.mock.On("GetFunctionUploadURL",
-		append([]interface{}{getFunctionUploadURLRequest}, requestOptions...)...)}
+func (_e *MockFunctionAPI_Expecter) ListCrons(listCronsRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_ListCrons_Call {
+	return &MockFunctionAPI_ListCrons_Call{Call: _e.mock.On("ListCrons",
+		append([]interface{}{listCronsRequest}, requestOptions...)...)}
 }
 
-func (_c *MockFunctionAPI_GetFunctionUploadURL_Call) Run(run func(getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetFunctionUploadURL_Call {
+func (_c *MockFunctionAPI_ListCrons_Call) Run(run func(listCronsRequest *function.ListCronsRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_ListCrons_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *function.GetFunctionUploadURLRequest
+		var arg0 *function.ListCronsRequest
 		if args[0] != nil {
-			arg0 = args[0].(*function.GetFunctionUploadURLRequest)
+			arg0 = args[0].(*function.ListCronsRequest)
 		}
 		var arg1 []scw.RequestOption
 		var variadicArgs []scw.RequestOption
@@ -643,68 +1407,68 @@ func (_c *MockFunctionAPI_GetFunctionUploadURL_Call) Run(run func(getFunctionUpl
 	return _c
 }
 
-func (_c *MockFunctionAPI_GetFunctionUploadURL_Call) Return(uploadURL *function.UploadURL, err error) *MockFunctionAPI_GetFunctionUploadURL_Call {
-	_c.Call.Return(uploadURL, err)
+func (_c *MockFunctionAPI_ListCrons_Call) Return(listCronsResponse *function.ListCronsResponse, err error) *MockFunctionAPI_ListCrons_Call {
+	_c.Call.Return(listCronsResponse, err)
 	return _c
 }
 
-func (_c *MockFunctionAPI_GetFunctionUploadURL_Call) RunAndReturn(run func(getFunctionUploadURLRequest *function.GetFunctionUploadURLRequest, requestOptions ...scw.RequestOption) (*function.UploadURL, error)) *MockFunctionAPI_GetFunctionUploadURL_Call {
+func (_c *MockFunctionAPI_ListCrons_Call) RunAndReturn(run func(listCronsRequest *function.ListCronsRequest, requestOptions ...scw.RequestOption) (*function.ListCronsResponse, error)) *MockFunctionAPI_ListCrons_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNamespace provides a mock function for the type MockFunctionAPI
-func (_mock *MockFunctionAPI) GetNamespace(getNamespaceRequest *function.GetNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error) {
+// ListDomains provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) ListDomains(listDomainsRequest *function.ListDomainsRequest, requestOptions ...scw.RequestOption) (*function.ListDomainsResponse, error) {
 	var tmpRet mock.Arguments
 	if len(requestOptions) > 0 {
-		tmpRet = _mock.Called(getNamespaceRequest, requestOptions)
+		tmpRet = _mock.Called(listDomainsRequest, requestOptions)
 	} else {
-		tmpRet = _mock.Called(getNamespaceRequest)
+		tmpRet = _mock.Called(listDomainsRequest)
 	}
 	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNamespace")
+		panic("no return value specified for ListDomains")
 	}
 
-	var r0 *function.Namespace
+	var r0 *function.ListDomainsResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*function.GetNamespaceRequest, ...scw.RequestOption) (*function.Namespace, error)); ok {
-		return returnFunc(getNamespaceRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.ListDomainsRequest, ...scw.RequestOption) (*function.ListDomainsResponse, error)); ok {
+		return returnFunc(listDomainsRequest, requestOptions...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*function.GetNamespaceRequest, ...scw.RequestOption) *function.Namespace); ok {
-		r0 = returnFunc(getNamespaceRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(0).(func(*function.ListDomainsRequest, ...scw.RequestOption) *function.ListDomainsResponse); ok {
+		r0 = returnFunc(listDomainsRequest, requestOptions...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*function.Namespace)
+			r0 = ret.Get(0).(*function.ListDomainsResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*function.GetNamespaceRequest, ...scw.RequestOption) error); ok {
-		r1 = returnFunc(getNamespaceRequest, requestOptions...)
+	if returnFunc, ok := ret.Get(1).(func(*function.ListDomainsRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(listDomainsRequest, requestOptions...)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFunctionAPI_GetNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNamespace'
-type MockFunctionAPI_GetNamespace_Call struct {
+// MockFunctionAPI_ListDomains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDomains'
+type MockFunctionAPI_ListDomains_Call struct {
 	*mock.Call
 }
 
-// GetNamespace is a helper method to define mock.On call
-//   - getNamespaceRequest *function.GetNamespaceRequest
+// ListDomains is a helper method to define mock.On call
+//   - listDomainsRequest *function.ListDomainsRequest
 //   - requestOptions ...scw.RequestOption
-func (_e *MockFunctionAPI_Expecter) GetNamespace(getNamespaceRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_GetNamespace_Call {
-	return &MockFunctionAPI_GetNamespace_Call{Call: _e.mock.On("GetNamespace",
-		append([]interface{}{getNamespaceRequest}, requestOptions...)...)}
+func (_e *MockFunctionAPI_Expecter) ListDomains(listDomainsRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_ListDomains_Call {
+	return &MockFunctionAPI_ListDomains_Call{Call: _e.mock.On("ListDomains",
+		append([]interface{}{listDomainsRequest}, requestOptions...)...)}
 }
 
-func (_c *MockFunctionAPI_GetNamespace_Call) Run(run func(getNamespaceRequest *function.GetNamespaceRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_GetNamespace_Call {
+func (_c *MockFunctionAPI_ListDomains_Call) Run(run func(listDomainsRequest *function.ListDomainsRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_ListDomains_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *function.GetNamespaceRequest
+		var arg0 *function.ListDomainsRequest
 		if args[0] != nil {
-			arg0 = args[0].(*function.GetNamespaceRequest)
+			arg0 = args[0].(*function.ListDomainsRequest)
 		}
 		var arg1 []scw.RequestOption
 		var variadicArgs []scw.RequestOption
@@ -720,12 +1484,12 @@ func (_c *MockFunctionAPI_GetNamespace_Call) Run(run func(getNamespaceRequest *f
 	return _c
 }
 
-func (_c *MockFunctionAPI_GetNamespace_Call) Return(namespace *function.Namespace, err error) *MockFunctionAPI_GetNamespace_Call {
-	_c.Call.Return(namespace, err)
+func (_c *MockFunctionAPI_ListDomains_Call) Return(listDomainsResponse *function.ListDomainsResponse, err error) *MockFunctionAPI_ListDomains_Call {
+	_c.Call.Return(listDomainsResponse, err)
 	return _c
 }
 
-func (_c *MockFunctionAPI_GetNamespace_Call) RunAndReturn(run func(getNamespaceRequest *function.GetNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error)) *MockFunctionAPI_GetNamespace_Call {
+func (_c *MockFunctionAPI_ListDomains_Call) RunAndReturn(run func(listDomainsRequest *function.ListDomainsRequest, requestOptions ...scw.RequestOption) (*function.ListDomainsResponse, error)) *MockFunctionAPI_ListDomains_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -961,6 +1725,206 @@ func (_c *MockFunctionAPI_ListNamespaces_Call) RunAndReturn(run func(listNamespa
 	return _c
 }
 
+// ListTokens provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) ListTokens(listTokensRequest *function.ListTokensRequest, requestOptions ...scw.RequestOption) (*function.ListTokensResponse, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(listTokensRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(listTokensRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTokens")
+	}
+
+	var r0 *function.ListTokensResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.ListTokensRequest, ...scw.RequestOption) (*function.ListTokensResponse, error)); ok {
+		return returnFunc(listTokensRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.ListTokensRequest, ...scw.RequestOption) *function.ListTokensResponse); ok {
+		r0 = returnFunc(listTokensRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.ListTokensResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.ListTokensRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(listTokensRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_ListTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTokens'
+type MockFunctionAPI_ListTokens_Call struct {
+	*mock.Call
+}
+
+// ListTokens is a helper method to define mock.On call
+//   - listTokensRequest *function.ListTokensRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) ListTokens(listTokensRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_ListTokens_Call {
+	return &MockFunctionAPI_ListTokens_Call{Call: _e.mock.On("ListTokens",
+		append([]interface{}{listTokensRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_ListTokens_Call) Run(run func(listTokensRequest *function.ListTokensRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_ListTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.ListTokensRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.ListTokensRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_ListTokens_Call) Return(listTokensResponse *function.ListTokensResponse, err error) *MockFunctionAPI_ListTokens_Call {
+	_c.Call.Return(listTokensResponse, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_ListTokens_Call) RunAndReturn(run func(listTokensRequest *function.ListTokensRequest, requestOptions ...scw.RequestOption) (*function.ListTokensResponse, error)) *MockFunctionAPI_ListTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Regions provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) Regions() []scw.Region {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Regions")
+	}
+
+	var r0 []scw.Region
+	if returnFunc, ok := ret.Get(0).(func() []scw.Region); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]scw.Region)
+		}
+	}
+	return r0
+}
+
+// MockFunctionAPI_Regions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Regions'
+type MockFunctionAPI_Regions_Call struct {
+	*mock.Call
+}
+
+// Regions is a helper method to define mock.On call
+func (_e *MockFunctionAPI_Expecter) Regions() *MockFunctionAPI_Regions_Call {
+	return &MockFunctionAPI_Regions_Call{Call: _e.mock.On("Regions")}
+}
+
+func (_c *MockFunctionAPI_Regions_Call) Run(run func()) *MockFunctionAPI_Regions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_Regions_Call) Return(regions []scw.Region) *MockFunctionAPI_Regions_Call {
+	_c.Call.Return(regions)
+	return _c
+}
+
+func (_c *MockFunctionAPI_Regions_Call) RunAndReturn(run func() []scw.Region) *MockFunctionAPI_Regions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCron provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) UpdateCron(updateCronRequest *function.UpdateCronRequest, requestOptions ...scw.RequestOption) (*function.Cron, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(updateCronRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(updateCronRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCron")
+	}
+
+	var r0 *function.Cron
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.UpdateCronRequest, ...scw.RequestOption) (*function.Cron, error)); ok {
+		return returnFunc(updateCronRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.UpdateCronRequest, ...scw.RequestOption) *function.Cron); ok {
+		r0 = returnFunc(updateCronRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Cron)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.UpdateCronRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(updateCronRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_UpdateCron_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCron'
+type MockFunctionAPI_UpdateCron_Call struct {
+	*mock.Call
+}
+
+// UpdateCron is a helper method to define mock.On call
+//   - updateCronRequest *function.UpdateCronRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) UpdateCron(updateCronRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_UpdateCron_Call {
+	return &MockFunctionAPI_UpdateCron_Call{Call: _e.mock.On("UpdateCron",
+		append([]interface{}{updateCronRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_UpdateCron_Call) Run(run func(updateCronRequest *function.UpdateCronRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_UpdateCron_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.UpdateCronRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.UpdateCronRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_UpdateCron_Call) Return(cron *function.Cron, err error) *MockFunctionAPI_UpdateCron_Call {
+	_c.Call.Return(cron, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_UpdateCron_Call) RunAndReturn(run func(updateCronRequest *function.UpdateCronRequest, requestOptions ...scw.RequestOption) (*function.Cron, error)) *MockFunctionAPI_UpdateCron_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateFunction provides a mock function for the type MockFunctionAPI
 func (_mock *MockFunctionAPI) UpdateFunction(updateFunctionRequest *function.UpdateFunctionRequest, requestOptions ...scw.RequestOption) (*function.Function, error) {
 	var tmpRet mock.Arguments
@@ -1038,6 +2002,83 @@ func (_c *MockFunctionAPI_UpdateFunction_Call) RunAndReturn(run func(updateFunct
 	return _c
 }
 
+// WaitForDomain provides a mock function for the type MockFunctionAPI
+func (_mock *MockFunctionAPI) WaitForDomain(waitForDomainRequest *function.WaitForDomainRequest, requestOptions ...scw.RequestOption) (*function.Domain, error) {
+	var tmpRet mock.Arguments
+	if len(requestOptions) > 0 {
+		tmpRet = _mock.Called(waitForDomainRequest, requestOptions)
+	} else {
+		tmpRet = _mock.Called(waitForDomainRequest)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForDomain")
+	}
+
+	var r0 *function.Domain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*function.WaitForDomainRequest, ...scw.RequestOption) (*function.Domain, error)); ok {
+		return returnFunc(waitForDomainRequest, requestOptions...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*function.WaitForDomainRequest, ...scw.RequestOption) *function.Domain); ok {
+		r0 = returnFunc(waitForDomainRequest, requestOptions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*function.Domain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*function.WaitForDomainRequest, ...scw.RequestOption) error); ok {
+		r1 = returnFunc(waitForDomainRequest, requestOptions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFunctionAPI_WaitForDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitForDomain'
+type MockFunctionAPI_WaitForDomain_Call struct {
+	*mock.Call
+}
+
+// WaitForDomain is a helper method to define mock.On call
+//   - waitForDomainRequest *function.WaitForDomainRequest
+//   - requestOptions ...scw.RequestOption
+func (_e *MockFunctionAPI_Expecter) WaitForDomain(waitForDomainRequest interface{}, requestOptions ...interface{}) *MockFunctionAPI_WaitForDomain_Call {
+	return &MockFunctionAPI_WaitForDomain_Call{Call: _e.mock.On("WaitForDomain",
+		append([]interface{}{waitForDomainRequest}, requestOptions...)...)}
+}
+
+func (_c *MockFunctionAPI_WaitForDomain_Call) Run(run func(waitForDomainRequest *function.WaitForDomainRequest, requestOptions ...scw.RequestOption)) *MockFunctionAPI_WaitForDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *function.WaitForDomainRequest
+		if args[0] != nil {
+			arg0 = args[0].(*function.WaitForDomainRequest)
+		}
+		var arg1 []scw.RequestOption
+		var variadicArgs []scw.RequestOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]scw.RequestOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFunctionAPI_WaitForDomain_Call) Return(domain *function.Domain, err error) *MockFunctionAPI_WaitForDomain_Call {
+	_c.Call.Return(domain, err)
+	return _c
+}
+
+func (_c *MockFunctionAPI_WaitForDomain_Call) RunAndReturn(run func(waitForDomainRequest *function.WaitForDomainRequest, requestOptions ...scw.RequestOption) (*function.Domain, error)) *MockFunctionAPI_WaitForDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WaitForNamespace provides a mock function for the type MockFunctionAPI
 func (_mock *MockFunctionAPI) WaitForNamespace(waitForNamespaceRequest *function.WaitForNamespaceRequest, requestOptions ...scw.RequestOption) (*function.Namespace, error) {
 	var tmpRet mock.Arguments
@@ -0,0 +1,27 @@
+// Package xdg locates the directories of the XDG Base Directory Specification.
+// Reference: https://specifications.freedesktop.org/basedir-spec/latest/
+package xdg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cyclimse/mcp-scaleway-functions/internal/constants"
+)
+
+// StateDir returns the directory where the server keeps its state (logs, history...).
+// The directory is not created.
+func StateDir() (string, error) {
+	xdgStateDir := os.Getenv("XDG_STATE_HOME")
+	if xdgStateDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("getting user home directory: %w", err)
+		}
+
+		xdgStateDir = filepath.Join(homeDir, ".local", "state")
+	}
+
+	return filepath.Join(xdgStateDir, constants.ProjectName), nil
+}
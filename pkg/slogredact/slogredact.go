@@ -0,0 +1,185 @@
+package slogredact
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// Mask is the value that replaces redacted fields.
+const Mask = "[REDACTED]"
+
+// wildcard matches any key of an object or any element of an array in a path.
+const wildcard = "*"
+
+//nolint:gochecknoglobals
+var secretPropertyName = regexp.MustCompile(`(?i)(secret|password|passwd|private_key|(^|_)token$)`)
+
+// Redactor masks fields of JSON-encoded log attributes.
+//
+// Paths are dot-separated, optionally prefixed with "$.", and "*" (or "[*]")
+// matches every key of an object or every element of an array.
+// For instance, "secret_environment_variables.*" masks the values of the
+// "secret_environment_variables" object while keeping its keys.
+// Keys are matched case-insensitively, as for HTTP headers.
+type Redactor struct {
+	paths [][]string
+}
+
+func New(paths ...string) *Redactor {
+	r := &Redactor{}
+
+	for _, p := range paths {
+		segments := parsePath(p)
+		if len(segments) == 0 {
+			continue
+		}
+
+		if !slices.ContainsFunc(r.paths, func(other []string) bool { return slices.Equal(other, segments) }) {
+			r.paths = append(r.paths, segments)
+		}
+	}
+
+	return r
+}
+
+func parsePath(p string) []string {
+	p = strings.TrimPrefix(strings.TrimSpace(p), "$")
+	p = strings.ReplaceAll(p, "[*]", "."+wildcard)
+	p = strings.Trim(p, ".")
+
+	if p == "" {
+		return nil
+	}
+
+	return strings.Split(p, ".")
+}
+
+// PathsFromSchema returns the paths of the secret fields of a JSON schema.
+// A field is considered secret when it is marked as "writeOnly", or when its name
+// looks like a secret (e.g. "secret_environment_variables", "password" or "token").
+// The values of secret objects are masked, but their keys are kept.
+func PathsFromSchema(schema *jsonschema.Schema) []string {
+	var paths []string
+
+	walkSchema(schema, nil, &paths)
+
+	return paths
+}
+
+func walkSchema(schema *jsonschema.Schema, prefix []string, paths *[]string) {
+	if schema == nil {
+		return
+	}
+
+	for name, property := range schema.Properties {
+		path := append(slices.Clone(prefix), name)
+
+		if property.WriteOnly || secretPropertyName.MatchString(name) {
+			if property.AdditionalProperties != nil || property.Items != nil {
+				path = append(path, wildcard)
+			}
+
+			*paths = append(*paths, strings.Join(path, "."))
+
+			continue
+		}
+
+		walkSchema(property, path, paths)
+	}
+
+	if schema.AdditionalProperties != nil {
+		walkSchema(schema.AdditionalProperties, append(slices.Clone(prefix), wildcard), paths)
+	}
+
+	if schema.Items != nil {
+		walkSchema(schema.Items, append(slices.Clone(prefix), wildcard), paths)
+	}
+}
+
+// RedactJSON masks the configured paths of a JSON document.
+// If the document cannot be parsed, it is returned unchanged.
+func (r *Redactor) RedactJSON(data []byte) []byte {
+	if len(r.paths) == 0 {
+		return data
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Avoid rewriting large numbers as floats.
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return data
+	}
+
+	for _, path := range r.paths {
+		v = redact(v, path)
+	}
+
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+
+	return redacted
+}
+
+func redact(v any, path []string) any {
+	// Nothing to hide in a null value, and it's useful to know it was set (e.g. to delete a secret).
+	if v == nil {
+		return nil
+	}
+
+	if len(path) == 0 {
+		return Mask
+	}
+
+	segment, rest := path[0], path[1:]
+
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if segment == wildcard || strings.EqualFold(segment, k) {
+				v[k] = redact(child, rest)
+			}
+		}
+	case []any:
+		index, err := strconv.Atoi(segment)
+
+		for i, child := range v {
+			if segment == wildcard || (err == nil && index == i) {
+				v[i] = redact(child, rest)
+			}
+		}
+	default:
+		// The value does not have the expected shape (e.g. a string where an object of secrets
+		// was expected): better safe than sorry.
+		return Mask
+	}
+
+	return v
+}
+
+// ReplaceAttr is meant to be used as the ReplaceAttr option of slog handlers.
+// It redacts string attributes which hold a JSON object or array.
+func (r *Redactor) ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() != slog.KindString {
+		return a
+	}
+
+	s := a.Value.String()
+
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return a
+	}
+
+	return slog.String(a.Key, string(r.RedactJSON([]byte(s))))
+}
@@ -0,0 +1,108 @@
+package slogredact
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathsFromSchema(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Password string `json:"password"`
+		Username string `json:"username"`
+	}
+
+	type input struct {
+		FunctionName               string             `json:"function_name"`
+		EnvironmentVariables       map[string]string  `json:"environment_variables"`
+		SecretEnvironmentVariables map[string]*string `json:"secret_environment_variables"`
+		Credentials                []nested           `json:"credentials"`
+	}
+
+	schema, err := jsonschema.For[input](&jsonschema.ForOptions{})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"secret_environment_variables.*",
+		"credentials.*.password",
+	}, PathsFromSchema(schema))
+}
+
+func TestRedactor_RedactJSON(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		paths []string
+		in    string
+		want  string
+	}{
+		{
+			name:  "mask object values but keep keys",
+			paths: []string{"secret_environment_variables.*"},
+			in:    `{"function_name":"my-function","secret_environment_variables":{"API_KEY":"hunter2","TO_DELETE":null}}`,
+			want:  `{"function_name":"my-function","secret_environment_variables":{"API_KEY":"[REDACTED]","TO_DELETE":null}}`,
+		},
+		{
+			name:  "unexpected shape is masked entirely",
+			paths: []string{"secret_environment_variables.*"},
+			in:    `{"secret_environment_variables":"API_KEY=hunter2"}`,
+			want:  `{"secret_environment_variables":"[REDACTED]"}`,
+		},
+		{
+			name:  "array wildcard and JSONPath prefix",
+			paths: []string{"$.headers[*].value"},
+			in:    `{"headers":[{"name":"Authorization","value":"Bearer xyz"}],"big":12345678901234567890}`,
+			want:  `{"big":12345678901234567890,"headers":[{"name":"Authorization","value":"[REDACTED]"}]}`,
+		},
+		{
+			name:  "keys are matched case-insensitively",
+			paths: []string{"$.headers.Authorization"},
+			in:    `{"headers":{"authorization":"Bearer xyz","Accept":"*/*"}}`,
+			want:  `{"headers":{"Accept":"*/*","authorization":"[REDACTED]"}}`,
+		},
+		{
+			name:  "invalid JSON is left untouched",
+			paths: []string{"password"},
+			in:    `{"password":`,
+			want:  `{"password":`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := New(tc.paths...).RedactJSON([]byte(tc.in))
+
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestRedactor_ReplaceAttr(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	redactor := New("secret_environment_variables.*")
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: redactor.ReplaceAttr,
+	}))
+
+	logger.Info(
+		"Starting request",
+		slog.String("tool_input", `{"secret_environment_variables":{"API_KEY":"hunter2"}}`),
+		slog.String("tool_name", "create_and_deploy_function"),
+	)
+
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.Contains(t, buf.String(), "API_KEY")
+	assert.Contains(t, buf.String(), "create_and_deploy_function")
+}